@@ -2,41 +2,78 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
+	"github.com/Lemper29/api-gateway/internal/auth"
 	"github.com/Lemper29/api-gateway/internal/config"
-	"github.com/Lemper29/api-gateway/internal/logger"
+	"github.com/Lemper29/api-gateway/internal/health"
+	"github.com/Lemper29/api-gateway/internal/metrics"
+	"github.com/Lemper29/api-gateway/internal/utils"
 	pb "github.com/Lemper29/auction/gen/auction"
+	"github.com/Lemper29/auction/pkg/logger"
+	"github.com/Lemper29/auction/pkg/tracing"
+	"github.com/google/uuid"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+// requestIDHeader связывает записи логов шлюза и auction-service об одном
+// запросе. Клиент может передать свой идентификатор, иначе шлюз создаёт новый.
+const requestIDHeader = "X-Request-ID"
+
 func loggingMiddleware(next http.Handler) http.Handler {
 	appLogger := logger.New(config.Envs.Env, config.Envs.LogLevel)
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
+		// В сервис идентификатор передаёт только forwardRequestID
+		r.Header.Del("Grpc-Metadata-" + requestIDHeader)
+		requestID := r.Header.Get(requestIDHeader)
+		if !logger.ValidRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+		w.Header().Set(requestIDHeader, requestID)
+		r = r.WithContext(logger.WithRequestID(r.Context(), requestID))
+
 		requestLogger := appLogger.With(
 			"method", r.Method,
 			"path", r.URL.Path,
 			"remote_addr", r.RemoteAddr,
 		)
 
-		requestLogger.Info("Request started")
+		requestLogger.InfoContext(r.Context(), "Request started")
 
 		rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		ctx, route := metrics.WithRoute(r.Context())
 
-		next.ServeHTTP(rw, r)
+		next.ServeHTTP(rw, r.WithContext(ctx))
 
 		duration := time.Since(start)
-		requestLogger.Info("Request completed",
+		requestLogger.InfoContext(r.Context(), "Request completed",
 			"status", rw.statusCode,
 			"duration", duration.String(),
 		)
+		metrics.HTTPRequests.WithLabelValues(r.Method, route(), strconv.Itoa(rw.statusCode)).Inc()
+		metrics.HTTPDuration.WithLabelValues(r.Method, route()).Observe(duration.Seconds())
 	})
 }
 
@@ -50,12 +87,128 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap нужен http.ResponseController: через него grpc-gateway сбрасывает
+// клиенту каждое сообщение подписки.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// cancelStreamsOnShutdown прерывает подписки, когда шлюз останавливается:
+// http.Server.Shutdown ждёт завершения запросов, но бесконечные потоки сам не прерывает.
+func cancelStreamsOnShutdown(shutdown context.Context, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/subscribe") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		stop := context.AfterFunc(shutdown, cancel)
+		defer stop()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// nameSpan — middleware gwMux: называет HTTP-спан по шаблону маршрута,
+// а не по пути, чтобы спаны одного метода API группировались вместе.
+func nameSpan(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		if pattern, ok := runtime.HTTPPattern(r.Context()); ok {
+			route := pattern.String()
+			span := trace.SpanFromContext(r.Context())
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(semconv.HTTPRoute(route))
+		}
+		next(w, r, pathParams)
+	}
+}
+
+// streamErrorHandler сообщает подписчикам, прерванным остановкой шлюза,
+// что нужно переподписаться, вместо безликой отмены запроса.
+func streamErrorHandler(shutdown context.Context) runtime.StreamErrorHandlerFunc {
+	return func(ctx context.Context, err error) *status.Status {
+		if shutdown.Err() == nil || status.Code(err) != codes.Canceled {
+			return runtime.DefaultStreamErrorHandler(ctx, err)
+		}
+
+		st, _ := status.New(codes.Unavailable, "server is going away, resubscribe").WithDetails(&errdetails.ErrorInfo{
+			Reason: "SERVER_GOING_AWAY",
+			Domain: "api-gateway",
+		})
+		return st
+	}
+}
+
+// errorHandler отдаёт ошибки сервиса в стандартном формате grpc-gateway
+// (code, message, details с ErrorInfo), но с 409 вместо 400 для FailedPrecondition.
+func errorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if status.Code(err) == codes.FailedPrecondition {
+		w = &statusOverrideWriter{ResponseWriter: w, statusCode: utils.HTTPStatusFromError(err)}
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
+}
+
+type statusOverrideWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (w *statusOverrideWriter) WriteHeader(int) {
+	w.ResponseWriter.WriteHeader(w.statusCode)
+}
+
+// forwardRequestID передаёт идентификатор запроса в метаданных gRPC-вызова.
+func forwardRequestID(ctx context.Context, r *http.Request) metadata.MD {
+	requestID, ok := logger.RequestID(r.Context())
+	if !ok {
+		return nil
+	}
+	return metadata.Pairs(strings.ToLower(requestIDHeader), requestID)
+}
+
+// headerMatcher дополнительно пересылает в сервис заголовок Idempotency-Key.
+func headerMatcher(key string) (string, bool) {
+	if http.CanonicalHeaderKey(key) == "Idempotency-Key" {
+		return "idempotency-key", true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
 func main() {
-	ctx := context.Background()
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	// Отменяется после остановки HTTP-сервера и закрывает соединения с auction-service
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		// Передаёт контекст трассировки в auction-service через traceparent
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
+		grpc.WithChainUnaryInterceptor(metrics.GRPCClient.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(metrics.GRPCClient.StreamClientInterceptor()),
+	}
+
+	if config.Envs.JWTSecret == "" {
+		log.Fatalf("JWT_SECRET is required")
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, "api-gateway", config.Envs.TracingExporter, config.Envs.TracingFile)
+	if err != nil {
+		log.Fatalf("Tracing err: %v", err)
+	}
+
+	streams, cancelStreams := context.WithCancel(context.Background())
+	defer cancelStreams()
 
-	gwMux := runtime.NewServeMux()
-	err := pb.RegisterAuctionServiceHandlerFromEndpoint(
+	gwMux := runtime.NewServeMux(
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithStreamErrorHandler(streamErrorHandler(streams)),
+		runtime.WithMetadata(auth.ForwardIdentity),
+		runtime.WithMetadata(forwardRequestID),
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+		runtime.WithMiddlewares(metrics.RecordRoute, nameSpan),
+	)
+	err = pb.RegisterAuctionServiceHandlerFromEndpoint(
 		ctx,
 		gwMux,
 		config.Envs.AddressAuctionService,
@@ -64,9 +217,95 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to register gRPC gateway: %v", err)
 	}
+	err = pb.RegisterUserServiceHandlerFromEndpoint(
+		ctx,
+		gwMux,
+		config.Envs.AddressAuctionService,
+		opts,
+	)
+	if err != nil {
+		log.Fatalf("Failed to register gRPC gateway: %v", err)
+	}
+
+	authMux := auth.Middleware(auth.NewVerifier(config.Envs.JWTSecret), gwMux, gwMux)
+	loggingMux := loggingMiddleware(cancelStreamsOnShutdown(streams, authMux))
+
+	healthConn, err := grpc.NewClient(config.Envs.AddressAuctionService, opts...)
+	if err != nil {
+		log.Fatalf("Failed to connect to auction service: %v", err)
+	}
+	defer healthConn.Close()
+	checker := health.NewChecker(healthConn, config.Envs.HealthCheckTimeout)
+
+	// Пробы оркестратора идут мимо логирования, трассировки и аутентификации
+	probeMux := http.NewServeMux()
+	probeMux.HandleFunc("GET /healthz", checker.Live)
+	probeMux.HandleFunc("GET /readyz", checker.Ready)
+
+	apiHandler := otelhttp.NewHandler(loggingMux, "api-gateway",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string { return r.Method }),
+	)
+	// API не монтируется в probeMux как "/": ServeMux выставил бы r.Pattern,
+	// и otelhttp переименовал бы спан, уже названный nameSpan по маршруту
+	rootHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h, pattern := probeMux.Handler(r); pattern != "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		apiHandler.ServeHTTP(w, r)
+	})
+
+	srv := &http.Server{
+		Addr:    ":" + config.Envs.PortApiGatewayService,
+		Handler: rootHandler,
+	}
+	srv.RegisterOnShutdown(cancelStreams)
+
+	// Метрики слушают отдельный порт, который не публикуется наружу
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("GET /metrics", metrics.Handler())
+	metricsServer := &http.Server{
+		Addr:    ":" + config.Envs.PortMetrics,
+		Handler: metricsMux,
+	}
+	go func() {
+		log.Println("Starting metrics server on :" + config.Envs.PortMetrics)
+		if err := metricsServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Metrics server failed: %v", err)
+		}
+	}()
 
-	loggingMux := loggingMiddleware(gwMux)
+	signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Println("Starting server on :" + config.Envs.PortApiGatewayService)
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		log.Fatal(err)
+	case <-signalCtx.Done():
+	}
+
+	log.Printf("Shutting down, timeout %s", config.Envs.ShutdownTimeout)
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), config.Envs.ShutdownTimeout)
+	defer cancelShutdown()
+
+	// Новые соединения больше не принимаются, текущие запросы дорабатывают
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("In-flight requests interrupted by shutdown timeout: %v", err)
+		srv.Close()
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("Server err: %v", err)
+	}
+	metricsServer.Shutdown(shutdownCtx)
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.Printf("Failed to flush traces: %v", err)
+	}
 
-	log.Println("Starting server on :" + config.Envs.PortApiGatewayService)
-	log.Fatal(http.ListenAndServe(":"+config.Envs.PortApiGatewayService, loggingMux))
+	log.Println("Server stopped")
 }
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Lemper29/auction/pkg/token"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UserIDMetadataKey — под этим ключом проверенный идентификатор
+// пользователя уходит в auction-service.
+const UserIDMetadataKey = "x-user-id"
+
+var ErrInvalidToken = token.ErrInvalidToken
+
+// Identity — пользователь, подтверждённый токеном доступа.
+type Identity struct {
+	UserID   string
+	Username string
+}
+
+// Verifier проверяет JWT, выпущенные auction-service, по общему секрету.
+type Verifier struct {
+	secret []byte
+}
+
+func NewVerifier(secret string) *Verifier {
+	return &Verifier{secret: []byte(secret)}
+}
+
+func (v *Verifier) Verify(raw string) (Identity, error) {
+	c, err := token.Verify(v.secret, raw)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	return Identity{UserID: c.UserID, Username: c.Username}, nil
+}
+
+type identityKey struct{}
+
+func FromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
+
+// Middleware проверяет заголовок Authorization до обращения к сервису.
+// Запросы без токена пропускаются: обязательность аутентификации
+// для конкретного метода решает auction-service.
+func Middleware(verifier *Verifier, mux *runtime.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Идентификатор пользователя задаёт только шлюз
+		r.Header.Del("Grpc-Metadata-" + UserIDMetadataKey)
+
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			writeUnauthenticated(mux, w, r, "authorization header must use the Bearer scheme")
+			return
+		}
+
+		identity, err := verifier.Verify(strings.TrimSpace(token))
+		if err != nil {
+			writeUnauthenticated(mux, w, r, ErrInvalidToken.Error())
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), identityKey{}, identity)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ForwardIdentity передаёт проверенного пользователя в метаданных gRPC-запроса.
+func ForwardIdentity(ctx context.Context, r *http.Request) metadata.MD {
+	identity, ok := FromContext(r.Context())
+	if !ok {
+		return nil
+	}
+	return metadata.Pairs(UserIDMetadataKey, identity.UserID)
+}
+
+func writeUnauthenticated(mux *runtime.ServeMux, w http.ResponseWriter, r *http.Request, message string) {
+	st, _ := status.New(codes.Unauthenticated, message).WithDetails(&errdetails.ErrorInfo{
+		Reason: "INVALID_TOKEN",
+		Domain: "api-gateway",
+	})
+	_, outbound := runtime.MarshalerForRequest(mux, r)
+	runtime.HTTPError(r.Context(), mux, outbound, w, r, st.Err())
+}
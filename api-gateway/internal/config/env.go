@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -12,9 +14,20 @@ type Config struct {
 	PublicHost            string
 	PortApiGatewayService string
 	PortAuctionService    string
+	// Внутренний порт /metrics, отдельный от публичного API
+	PortMetrics           string
 	AddressAuctionService string
 	Env                   string
 	LogLevel              slog.Level
+	// Должен совпадать с JWT_SECRET в auction-service
+	JWTSecret string
+	// Куда отправлять спаны: none, otlp, stdout или file (в TracingFile)
+	TracingExporter string
+	TracingFile     string
+	// Сколько ждать завершения текущих запросов при остановке
+	ShutdownTimeout time.Duration
+	// Сколько /readyz ждёт ответа health-сервиса auction-service
+	HealthCheckTimeout time.Duration
 }
 
 var Envs = initConfig()
@@ -47,9 +60,15 @@ func initConfig() Config {
 		PublicHost:            publicHost,
 		PortApiGatewayService: portApiGatewayService,
 		PortAuctionService:    portAuctionService,
+		PortMetrics:           getEnv("PORT_GATEWAY_METRICS", "9091"),
 		AddressAuctionService: fmt.Sprintf("%s:%s", publicHost, portAuctionService),
 		Env:                   env,
 		LogLevel:              logLevel,
+		JWTSecret:             getEnv("JWT_SECRET", ""),
+		TracingExporter:       getEnv("TRACING_EXPORTER", "none"),
+		TracingFile:           getEnv("TRACING_FILE", "traces.json"),
+		ShutdownTimeout:       time.Duration(getEnvInt("SHUTDOWN_TIMEOUT_SECONDS", 15)) * time.Second,
+		HealthCheckTimeout:    time.Duration(getEnvInt("HEALTH_CHECK_TIMEOUT_SECONDS", 2)) * time.Second,
 	}
 }
 
@@ -59,3 +78,12 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getEnvInt(key string, fallback int) int {
+	if value, ok := os.LookupEnv(key); ok {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return fallback
+}
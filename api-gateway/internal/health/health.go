@@ -0,0 +1,56 @@
+// Package health отдаёт пробы для оркестратора: /healthz сообщает, что
+// процесс шлюза жив, /readyz — что auction-service готов обслуживать запросы.
+package health
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Lemper29/api-gateway/internal/utils"
+	pb "github.com/Lemper29/auction/gen/auction"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type Checker struct {
+	client  healthpb.HealthClient
+	timeout time.Duration
+}
+
+func NewChecker(conn grpc.ClientConnInterface, timeout time.Duration) *Checker {
+	return &Checker{
+		client:  healthpb.NewHealthClient(conn),
+		timeout: timeout,
+	}
+}
+
+// Live отвечает 200, пока шлюз способен обрабатывать HTTP-запросы.
+// Состояние auction-service на неё не влияет, иначе оркестратор
+// перезапускал бы шлюз из-за сбоев за его пределами.
+func (c *Checker) Live(w http.ResponseWriter, r *http.Request) {
+	utils.WriteJSON(w, http.StatusOK, map[string]string{"status": "SERVING"})
+}
+
+// Ready отвечает 200, только если auction-service сообщает SERVING.
+func (c *Checker) Ready(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), c.timeout)
+	defer cancel()
+
+	resp, err := c.client.Check(ctx, &healthpb.HealthCheckRequest{
+		Service: pb.AuctionService_ServiceDesc.ServiceName,
+	})
+	if err != nil {
+		utils.WriteJSON(w, http.StatusServiceUnavailable, map[string]string{
+			"status": healthpb.HealthCheckResponse_NOT_SERVING.String(),
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	code := http.StatusOK
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		code = http.StatusServiceUnavailable
+	}
+	utils.WriteJSON(w, code, map[string]string{"status": resp.GetStatus().String()})
+}
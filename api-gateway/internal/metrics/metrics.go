@@ -0,0 +1,68 @@
+// Package metrics описывает метрики Prometheus шлюза. Все метрики
+// регистрируются в реестре по умолчанию и отдаются через Handler.
+package metrics
+
+import (
+	"context"
+	"net/http"
+
+	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "gateway"
+
+// unmatchedRoute — метка запросов, для которых не нашлось маршрута.
+const unmatchedRoute = "unmatched"
+
+// GRPCClient — метрики вызовов auction-service: число вызовов по методу
+// и коду ответа и гистограмма длительности.
+var GRPCClient = grpcprom.NewClientMetrics(grpcprom.WithClientHandlingTimeHistogram())
+
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests, by method, route and status code.",
+	}, []string{"method", "route", "code"})
+
+	HTTPDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency, by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+)
+
+func init() {
+	prometheus.MustRegister(GRPCClient)
+}
+
+// Handler отдаёт метрики в формате Prometheus.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+type routeKey struct{}
+
+// WithRoute готовит в контексте место, куда RecordRoute запишет шаблон
+// маршрута. Метки по шаблону, а не по пути, не разрастаются от id лотов.
+func WithRoute(ctx context.Context) (context.Context, func() string) {
+	route := unmatchedRoute
+	return context.WithValue(ctx, routeKey{}, &route), func() string { return route }
+}
+
+// RecordRoute — middleware gwMux, запоминающий сопоставленный шаблон маршрута.
+func RecordRoute(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		if route, ok := r.Context().Value(routeKey{}).(*string); ok {
+			if pattern, ok := runtime.HTTPPattern(r.Context()); ok {
+				*route = pattern.String()
+			}
+		}
+		next(w, r, pathParams)
+	}
+}
@@ -4,6 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func ParseJSON(r *http.Request, payload any) error {
@@ -24,3 +28,13 @@ func WriteJSON(w http.ResponseWriter, status int, v any) error {
 func WriteError(w http.ResponseWriter, status int, err error) {
 	WriteJSON(w, status, map[string]string{"error": err.Error()})
 }
+
+// HTTPStatusFromError переводит ошибку gRPC в HTTP-статус. FailedPrecondition
+// означает конфликт с текущим состоянием лота, поэтому отдаётся как 409.
+func HTTPStatusFromError(err error) int {
+	code := status.Code(err)
+	if code == codes.FailedPrecondition {
+		return http.StatusConflict
+	}
+	return runtime.HTTPStatusFromCode(code)
+}
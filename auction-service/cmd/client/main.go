@@ -6,9 +6,13 @@ import (
 	"io"
 	"log"
 
+	"github.com/Lemper29/auction-service/pkg/money"
 	pb "github.com/Lemper29/auction/gen/auction"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func main() {
@@ -21,6 +25,7 @@ func main() {
 	defer conn.Close()
 
 	client := pb.NewAuctionServiceClient(conn)
+	users := pb.NewUserServiceClient(conn)
 
 	for {
 		// Меню выбора
@@ -28,6 +33,8 @@ func main() {
 		fmt.Println("1 - Создать лот")
 		fmt.Println("2 - Подписаться на лот")
 		fmt.Println("3 - Сделать ставку")
+		fmt.Println("4 - Регистрация")
+		fmt.Println("5 - Вход")
 		fmt.Println("0 - Выход")
 		fmt.Print("Введите номер: ")
 
@@ -41,6 +48,10 @@ func main() {
 			subscribeToLotInteractive(client, ctx)
 		case 3:
 			placeBidInteractive(client, ctx)
+		case 4:
+			registerInteractive(users, ctx)
+		case 5:
+			ctx = loginInteractive(users, ctx)
 		case 0:
 			fmt.Println("До свидания!")
 			return
@@ -51,8 +62,7 @@ func main() {
 }
 
 func createLotInteractive(client pb.AuctionServiceClient, ctx context.Context) {
-	var name, description string
-	var startPrice float64
+	var name, description, startPriceInput string
 	var durationMinute int64
 
 	fmt.Print("Введите название лота: ")
@@ -62,7 +72,13 @@ func createLotInteractive(client pb.AuctionServiceClient, ctx context.Context) {
 	fmt.Scanln(&description)
 
 	fmt.Print("Введите стартовую цену: ")
-	fmt.Scanln(&startPrice)
+	fmt.Scanln(&startPriceInput)
+
+	startPrice, err := parseMoney(startPriceInput)
+	if err != nil {
+		log.Printf("Неверная сумма: %v", err)
+		return
+	}
 
 	fmt.Print("Введите длительность аукциона (минуты): ")
 	fmt.Scanln(&durationMinute)
@@ -81,7 +97,8 @@ func createLotInteractive(client pb.AuctionServiceClient, ctx context.Context) {
 	// ВЫВОДИМ ID ДЛЯ ПОЛЬЗОВАТЕЛЯ!
 	fmt.Printf("✅ Лот создан! ID: %s\n", createLot.Lot.Id)
 	fmt.Printf("   Название: %s\n", createLot.Lot.Name)
-	fmt.Printf("   Стартовая цена: %.2f\n", createLot.Lot.StartPrice)
+	fmt.Printf("   Стартовая цена: %s\n", formatMoney(createLot.Lot.StartPrice))
+	fmt.Printf("   Статус: %s\n", createLot.Lot.Status)
 }
 
 func subscribeToLotInteractive(client pb.AuctionServiceClient, ctx context.Context) {
@@ -119,40 +136,126 @@ func subscribeToLotInteractive(client pb.AuctionServiceClient, ctx context.Conte
 			break
 		}
 
-		fmt.Printf("📢 Обновление: %s - цена: %.2f, победитель: %s\n",
+		fmt.Printf("📢 Обновление: %s - цена: %s, победитель: %s\n",
 			lot.Lot.Name,
-			lot.Lot.CurrentPrice,
+			formatMoney(lot.Lot.CurrentPrice),
 			lot.Lot.CurrentWinner)
 	}
 }
 
 func placeBidInteractive(client pb.AuctionServiceClient, ctx context.Context) {
-	var lotID, userID string
-	var amount float64
+	var lotID, amountInput, maxAmountInput string
 
 	fmt.Print("Введите ID лота: ")
 	fmt.Scanln(&lotID)
 
-	fmt.Print("Введите ваш ID пользователя: ")
-	fmt.Scanln(&userID)
-
 	fmt.Print("Введите сумму ставки: ")
-	fmt.Scanln(&amount)
+	fmt.Scanln(&amountInput)
+
+	fmt.Print("Введите максимальную сумму автоставки (0 - без автоставки): ")
+	fmt.Scanln(&maxAmountInput)
+
+	amount, err := parseMoney(amountInput)
+	if err != nil {
+		log.Printf("Неверная сумма: %v", err)
+		return
+	}
+	maxAmount, err := parseMoney(maxAmountInput)
+	if err != nil {
+		log.Printf("Неверная сумма: %v", err)
+		return
+	}
 
 	response, err := client.PlaceBid(ctx, &pb.PlaceBidRequest{
-		LotId:  lotID,
-		UserId: userID,
-		Amount: amount,
+		LotId:     lotID,
+		Amount:    amount,
+		MaxAmount: maxAmount,
 	})
 	if err != nil {
-		log.Printf("Ошибка ставки: %v", err)
+		st := status.Convert(err)
+		fmt.Printf("❌ Ставка отклонена (%s): %s\n", errorReason(st), st.Message())
 		return
 	}
 
-	if response.Success {
-		fmt.Println("✅ Ставка принята!")
-		fmt.Printf("   Текущая цена: %.2f\n", response.UpdatedLot.CurrentPrice)
-	} else {
-		fmt.Printf("❌ Ставка отклонена: %s\n", response.Message)
+	fmt.Println("✅ Ставка принята!")
+	fmt.Printf("   Текущая цена: %s\n", formatMoney(response.UpdatedLot.CurrentPrice))
+	fmt.Printf("   Минимальная следующая ставка: %s\n", formatMoney(response.UpdatedLot.NextMinBid))
+}
+
+// errorReason достаёт машиночитаемую причину из ErrorInfo, если она есть
+func errorReason(st *status.Status) string {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
 	}
+	return st.Code().String()
+}
+
+func registerInteractive(users pb.UserServiceClient, ctx context.Context) {
+	var username, password, role string
+
+	fmt.Print("Введите имя пользователя: ")
+	fmt.Scanln(&username)
+
+	fmt.Print("Введите пароль: ")
+	fmt.Scanln(&password)
+
+	fmt.Print("Роль (bidder - участник, seller - продавец): ")
+	fmt.Scanln(&role)
+
+	res, err := users.Register(ctx, &pb.RegisterRequest{
+		Username: username,
+		Password: password,
+		Role:     role,
+	})
+	if err != nil {
+		st := status.Convert(err)
+		fmt.Printf("❌ Регистрация не удалась (%s): %s\n", errorReason(st), st.Message())
+		return
+	}
+
+	fmt.Printf("✅ Пользователь %s (%s) зарегистрирован, ID: %s\n", res.User.Username, res.User.Role, res.User.Id)
+}
+
+// loginInteractive возвращает контекст, в котором токен передаётся с каждым запросом
+func loginInteractive(users pb.UserServiceClient, ctx context.Context) context.Context {
+	var username, password string
+
+	fmt.Print("Введите имя пользователя: ")
+	fmt.Scanln(&username)
+
+	fmt.Print("Введите пароль: ")
+	fmt.Scanln(&password)
+
+	res, err := users.Login(context.Background(), &pb.LoginRequest{
+		Username: username,
+		Password: password,
+	})
+	if err != nil {
+		st := status.Convert(err)
+		fmt.Printf("❌ Вход не удался (%s): %s\n", errorReason(st), st.Message())
+		return ctx
+	}
+
+	fmt.Printf("✅ Вы вошли как %s\n", res.User.Username)
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+res.AccessToken)
+}
+
+// parseMoney разбирает сумму вида "1500.50" в валюте по умолчанию
+func parseMoney(input string) (*pb.Money, error) {
+	if input == "" {
+		input = "0"
+	}
+
+	m, err := money.Parse(input, money.DefaultCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Money{CurrencyCode: m.Currency, MinorUnits: m.Amount}, nil
+}
+
+func formatMoney(m *pb.Money) string {
+	return money.New(m.GetMinorUnits(), m.GetCurrencyCode()).String()
 }
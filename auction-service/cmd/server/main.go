@@ -1,37 +1,223 @@
 package main
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"flag"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
+	"github.com/Lemper29/auction-service/internal/auth"
 	"github.com/Lemper29/auction-service/internal/config"
-	"github.com/Lemper29/auction-service/internal/logger"
+	"github.com/Lemper29/auction-service/internal/events"
+	"github.com/Lemper29/auction-service/internal/metrics"
 	"github.com/Lemper29/auction-service/internal/server"
+	"github.com/Lemper29/auction-service/internal/service"
+	"github.com/Lemper29/auction-service/internal/storage"
 	"github.com/Lemper29/auction-service/internal/storage/db"
+	"github.com/Lemper29/auction-service/internal/storage/memory"
+	"github.com/Lemper29/auction-service/pkg/models"
+	"github.com/Lemper29/auction/pkg/logger"
+	"github.com/Lemper29/auction/pkg/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"google.golang.org/grpc/health"
 	"gorm.io/driver/postgres"
 )
 
 func main() {
+	migrateOnly := flag.Bool("migrate-only", false, "apply database migrations and exit")
+	flag.Parse()
+
 	appLogger := logger.New("auction-service", config.Envs.LogLevel)
 	appLogger.Info("Starting auction service", "version", "1.0.0")
 
-	dsn := postgres.Config{
-		DSN:                  config.Envs.DSN,
-		PreferSimpleProtocol: true,
+	shutdownTracing, err := tracing.Setup(context.Background(), "auction-service", config.Envs.TracingExporter, config.Envs.TracingFile)
+	if err != nil {
+		log.Fatalf("Tracing err: %v", err)
+	}
+
+	var repo storage.Storage
+	switch config.Envs.StorageDriver {
+	case "memory":
+		if *migrateOnly {
+			log.Fatalf("--migrate-only requires postgres storage")
+		}
+		repo = memory.NewMemoryStorage()
+		appLogger.Warn("Using in-memory storage, data will be lost on restart")
+	case "postgres":
+		dsn := postgres.Config{
+			DSN:                  config.Envs.DSN,
+			PreferSimpleProtocol: true,
+		}
+
+		pgStorage, err := db.NewPostgresDB(dsn)
+		if err != nil {
+			appLogger.Error("Database connection failed", "error", err)
+			log.Fatalf("Database err: %v", err)
+		}
+		repo = pgStorage
+
+		appLogger.Info("Database connection established")
+
+		sqlDB, err := pgStorage.SQLDB()
+		if err != nil {
+			log.Fatalf("Database err: %v", err)
+		}
+		prometheus.MustRegister(collectors.NewDBStatsCollector(sqlDB, config.Envs.DBName))
+
+		// Сервис не должен работать со схемой, отстающей от кода
+		if *migrateOnly || config.Envs.AutoMigrate {
+			if err := pgStorage.Migrate(context.Background()); err != nil {
+				log.Fatalf("Migration err: %v", err)
+			}
+		} else if err := pgStorage.CheckSchema(context.Background()); err != nil {
+			log.Fatalf("Schema check err: %v", err)
+		}
+		if *migrateOnly {
+			appLogger.Info("Migrations applied")
+			return
+		}
+	default:
+		log.Fatalf("Unknown storage driver: %q", config.Envs.StorageDriver)
 	}
 
-	storage, err := db.NewPostgresDB(dsn)
+	hub := events.NewHub(
+		config.Envs.SubscriberBufferSize,
+		events.Policy(config.Envs.SlowSubscriberPolicy),
+		appLogger,
+	)
+	prometheus.MustRegister(metrics.NewSubscriptionCollector(hub))
+
+	// Фоновые задачи останавливаются последними, после завершения текущих вызовов
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	var jobs sync.WaitGroup
+	runJob := func(run func(ctx context.Context)) {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			run(jobsCtx)
+		}()
+	}
+
+	healthServer := health.NewServer()
+	monitor := service.NewHealthMonitor(
+		repo,
+		healthServer,
+		config.Envs.HealthCheckInterval,
+		config.Envs.HealthPingTimeout,
+		config.Envs.HealthJobStall,
+		appLogger,
+	)
+
+	closer := service.NewAuctionCloser(
+		repo,
+		hub,
+		config.Envs.CloserInterval,
+		config.Envs.CloserBatchSize,
+		monitor.Heartbeat("auction-closer", config.Envs.CloserInterval),
+		appLogger,
+	)
+	runJob(closer.Run)
+
+	activator := service.NewLotActivator(
+		repo,
+		hub,
+		config.Envs.ActivatorInterval,
+		config.Envs.ActivatorBatchSize,
+		monitor.Heartbeat("lot-activator", config.Envs.ActivatorInterval),
+		appLogger,
+	)
+	runJob(activator.Run)
+
+	cleaner := service.NewIdempotencyCleaner(
+		repo,
+		config.Envs.IdempotencyCleanupInterval,
+		monitor.Heartbeat("idempotency-cleaner", config.Envs.IdempotencyCleanupInterval),
+		appLogger,
+	)
+	runJob(cleaner.Run)
+
+	runJob(monitor.Run)
+
+	defaultIncrement, err := models.ParseIncrementRule(config.Envs.DefaultBidIncrement)
 	if err != nil {
-		appLogger.Error("Database connection failed", "error", err)
-		log.Fatalf("Database err: %v", err)
+		log.Fatalf("DEFAULT_BID_INCREMENT err: %v", err)
 	}
 
-	appLogger.Info("Database connection established")
+	if config.Envs.JWTSecret == "" {
+		log.Fatalf("JWT_SECRET is required")
+	}
+	tokens := auth.NewTokenManager(config.Envs.JWTSecret, config.Envs.JWTTokenTTL)
+
+	// Без отдельного секрета ключ выводится из JWT_SECRET, чтобы сам секрет
+	// подписи токенов не использовался для другой цели
+	aliasKey := []byte(config.Envs.BidderAliasSecret)
+	if len(aliasKey) == 0 {
+		mac := hmac.New(sha256.New, []byte(config.Envs.JWTSecret))
+		mac.Write([]byte("bidder-alias"))
+		aliasKey = mac.Sum(nil)
+	}
+
+	serve := server.NewGrpcServer(":"+config.Envs.PortAuctionService, repo, hub, defaultIncrement, config.Envs.IdempotencyTTL, config.Envs.CallTimeout, aliasKey, tokens, healthServer, appLogger)
 
-	serve := server.NewGrpcServer(":"+config.Envs.PortAuctionService, storage, appLogger)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("GET /metrics", metrics.Handler())
+	metricsServer := &http.Server{
+		Addr:    ":" + config.Envs.PortMetrics,
+		Handler: metricsMux,
+	}
+	go func() {
+		appLogger.Info("Metrics server starting", "port", config.Envs.PortMetrics)
+		if err := metricsServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			appLogger.Error("Metrics server failed", "error", err)
+		}
+	}()
 
 	appLogger.Info("Server starting", "port", config.Envs.PortAuctionService)
-	if err := serve.Start(); err != nil {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- serve.Start()
+	}()
+
+	select {
+	case err := <-serveErr:
 		appLogger.Error("Server failed to start", "error", err)
 		log.Fatalf("Server err: %v", err)
+	case <-ctx.Done():
+	}
+
+	appLogger.Info("Shutting down", "timeout", config.Envs.ShutdownTimeout.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.Envs.ShutdownTimeout)
+	defer cancel()
+
+	// Балансировщик перестаёт направлять сюда новые запросы
+	healthServer.Shutdown()
+
+	// Подписки живут бесконечно, поэтому их закрываем до ожидания остальных вызовов
+	hub.Close()
+	if err := serve.Shutdown(shutdownCtx); err != nil {
+		appLogger.Warn("In-flight calls interrupted by shutdown timeout", "error", err)
+	}
+	metricsServer.Shutdown(shutdownCtx)
+
+	stopJobs()
+	jobs.Wait()
+
+	if err := repo.Close(); err != nil {
+		appLogger.Error("Failed to close storage", "error", err)
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		appLogger.Warn("Failed to flush traces", "error", err)
 	}
+	appLogger.Info("Server stopped")
 }
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Lemper29/auction-service/pkg/models"
+	"github.com/Lemper29/auction/pkg/token"
+)
+
+var ErrInvalidToken = token.ErrInvalidToken
+
+// Identity — аутентифицированный пользователь, извлечённый из токена доступа.
+type Identity struct {
+	UserID   string
+	Username string
+	Role     string
+}
+
+// HasRole сообщает, есть ли у пользователя одна из ролей.
+func (i Identity) HasRole(roles ...string) bool {
+	return slices.Contains(roles, i.Role)
+}
+
+// CanManageLot — изменять и отменять лот может только его продавец или администратор.
+func (i Identity) CanManageLot(lot *models.Lot) bool {
+	return i.Role == models.RoleAdmin || (lot.SellerID != "" && lot.SellerID == i.UserID)
+}
+
+// TokenManager выпускает и проверяет токены доступа в формате пакета token.
+// Тот же секрет использует api-gateway для проверки токенов на входе.
+type TokenManager struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewTokenManager(secret string, ttl time.Duration) *TokenManager {
+	return &TokenManager{
+		secret: []byte(secret),
+		ttl:    ttl,
+	}
+}
+
+// Issue выпускает токен доступа для пользователя.
+func (t *TokenManager) Issue(user *models.User, now time.Time) (string, time.Time, error) {
+	expiresAt := now.Add(t.ttl)
+
+	signed, err := token.Sign(t.secret, token.Claims{
+		UserID:   user.ID,
+		Username: user.Username,
+		Role:     user.Role,
+	}, now, expiresAt)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// Parse проверяет подпись и срок действия токена.
+func (t *TokenManager) Parse(raw string) (Identity, error) {
+	c, err := token.Verify(t.secret, raw)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	// Токены, выпущенные до появления ролей, считаются токенами участника торгов
+	role := c.Role
+	if role == "" {
+		role = models.RoleBidder
+	}
+
+	return Identity{UserID: c.UserID, Username: c.Username, Role: role}, nil
+}
+
+type identityKey struct{}
+
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext возвращает пользователя, аутентифицированного интерсептором.
+func FromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -11,13 +13,57 @@ import (
 type Config struct {
 	PublicHost         string
 	PortAuctionService string
-	DBUser             string
-	DBPassword         string
-	DBAddress          string
-	DBName             string
-	DBPort             string
-	DSN                string
-	LogLevel           slog.Level
+	// Порт HTTP-сервера с /metrics для Prometheus
+	PortMetrics   string
+	DBUser        string
+	DBPassword    string
+	DBAddress     string
+	DBName        string
+	DBPort        string
+	DSN           string
+	StorageDriver string
+	LogLevel      slog.Level
+
+	// Применять миграции при старте; иначе сервис только проверяет версию схемы
+	AutoMigrate bool
+
+	// Куда отправлять спаны: none, otlp, stdout или file (в TracingFile)
+	TracingExporter string
+	TracingFile     string
+
+	// Дедлайн unary-вызовов, для которых его не задал клиент
+	CallTimeout time.Duration
+
+	// Сколько ждать завершения текущих вызовов при остановке
+	ShutdownTimeout time.Duration
+
+	// Проверка готовности для grpc.health.v1: пинг базы и работа фоновых задач
+	HealthCheckInterval time.Duration
+	HealthPingTimeout   time.Duration
+	HealthJobStall      time.Duration
+
+	SubscriberBufferSize int
+	SlowSubscriberPolicy string
+
+	CloserInterval  time.Duration
+	CloserBatchSize int
+
+	ActivatorInterval  time.Duration
+	ActivatorBatchSize int
+
+	// Правило шага ставки для лотов, у которых оно не задано при создании
+	DefaultBidIncrement string
+
+	// Секрет подписи JWT; должен совпадать с JWT_SECRET в api-gateway
+	JWTSecret   string
+	JWTTokenTTL time.Duration
+
+	// Ключ псевдонимов участников в ListBids; если не задан, выводится из JWTSecret
+	BidderAliasSecret string
+
+	// Сколько хранится ответ на запрос с ключом идемпотентности
+	IdempotencyTTL             time.Duration
+	IdempotencyCleanupInterval time.Duration
 }
 
 var Envs = InitConfig()
@@ -50,6 +96,7 @@ func InitConfig() *Config {
 	return &Config{
 		PublicHost:         getEnv("PUBLIC_HOST", "http://localhost"),
 		PortAuctionService: getEnv("PORT_AUCTION_SERVICE", "8080"),
+		PortMetrics:        getEnv("PORT_METRICS", "9090"),
 		DBUser:             dbUser,
 		DBPassword:         dbPassword,
 		DBAddress:          fmt.Sprintf("%s:%s", dbHost, dbPort),
@@ -57,7 +104,40 @@ func InitConfig() *Config {
 		DBPort:             dbPort,
 		DSN: fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
 			dbHost, dbPort, dbUser, dbPassword, dbName),
-		LogLevel: logLevel,
+		StorageDriver: getEnv("STORAGE_DRIVER", "postgres"),
+		LogLevel:      logLevel,
+
+		AutoMigrate: getEnv("AUTO_MIGRATE", "true") == "true",
+
+		TracingExporter: getEnv("TRACING_EXPORTER", "none"),
+		TracingFile:     getEnv("TRACING_FILE", "traces.json"),
+
+		CallTimeout: time.Duration(getEnvInt("GRPC_CALL_TIMEOUT_SECONDS", 10)) * time.Second,
+
+		ShutdownTimeout: time.Duration(getEnvInt("SHUTDOWN_TIMEOUT_SECONDS", 15)) * time.Second,
+
+		HealthCheckInterval: time.Duration(getEnvPositiveInt("HEALTH_CHECK_INTERVAL_SECONDS", 5)) * time.Second,
+		HealthPingTimeout:   time.Duration(getEnvInt("HEALTH_PING_TIMEOUT_SECONDS", 2)) * time.Second,
+		HealthJobStall:      time.Duration(getEnvInt("HEALTH_JOB_STALL_SECONDS", 60)) * time.Second,
+
+		SubscriberBufferSize: getEnvInt("SUBSCRIBER_BUFFER_SIZE", 16),
+		SlowSubscriberPolicy: getEnv("SLOW_SUBSCRIBER_POLICY", "drop_oldest"),
+
+		CloserInterval:  time.Duration(getEnvPositiveInt("CLOSER_INTERVAL_SECONDS", 1)) * time.Second,
+		CloserBatchSize: getEnvPositiveInt("CLOSER_BATCH_SIZE", 100),
+
+		ActivatorInterval:  time.Duration(getEnvPositiveInt("ACTIVATOR_INTERVAL_SECONDS", 1)) * time.Second,
+		ActivatorBatchSize: getEnvPositiveInt("ACTIVATOR_BATCH_SIZE", 100),
+
+		DefaultBidIncrement: getEnv("DEFAULT_BID_INCREMENT", "percent:100"),
+
+		JWTSecret:   getEnv("JWT_SECRET", ""),
+		JWTTokenTTL: time.Duration(getEnvInt("JWT_TTL_MINUTES", 60)) * time.Minute,
+
+		BidderAliasSecret: getEnv("BIDDER_ALIAS_SECRET", ""),
+
+		IdempotencyTTL:             time.Duration(getEnvInt("IDEMPOTENCY_TTL_HOURS", 24)) * time.Hour,
+		IdempotencyCleanupInterval: time.Duration(getEnvPositiveInt("IDEMPOTENCY_CLEANUP_INTERVAL_MINUTES", 10)) * time.Minute,
 	}
 }
 
@@ -67,3 +147,22 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getEnvInt(key string, fallback int) int {
+	if value, ok := os.LookupEnv(key); ok {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return fallback
+}
+
+// getEnvPositiveInt — getEnvInt для периодов и размеров пакетов фоновых задач:
+// time.NewTicker паникует на неположительном интервале, а пустой пакет
+// зацикливает выборку, поэтому такое значение заменяется значением по умолчанию.
+func getEnvPositiveInt(key string, fallback int) int {
+	if i := getEnvInt(key, fallback); i > 0 {
+		return i
+	}
+	return fallback
+}
@@ -0,0 +1,225 @@
+package events
+
+import (
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/Lemper29/auction-service/pkg/models"
+)
+
+type Type string
+
+const (
+	LotCreated       Type = "LOT_CREATED"
+	LotUpdated       Type = "LOT_UPDATED"
+	BidPlaced        Type = "BID_PLACED"
+	LotStatusChanged Type = "LOT_STATUS_CHANGED"
+	LotClosed        Type = "LOT_CLOSED"
+	LotCancelled     Type = "LOT_CANCELLED"
+)
+
+type Event struct {
+	Type Type
+	Lot  models.Lot
+}
+
+// Policy определяет, что делать с подписчиком, чей буфер переполнен
+type Policy string
+
+const (
+	// DropOldest выбрасывает самое старое событие из буфера: каждое событие
+	// содержит полный снимок лота, поэтому подписчик всё равно увидит актуальное состояние
+	DropOldest Policy = "drop_oldest"
+	// Disconnect отключает медленного подписчика
+	Disconnect Policy = "disconnect"
+)
+
+type Subscription struct {
+	topic string
+	ch    chan Event
+}
+
+// Events возвращает канал событий лота или пользователя. Канал закрывается, когда хаб
+// отключает подписчика или подписка отменена.
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+type Hub struct {
+	mu sync.RWMutex
+	// Подписки по топикам: id лота или userTopic пользователя
+	subs       map[string]map[*Subscription]struct{}
+	bufferSize int
+	policy     Policy
+	logger     *slog.Logger
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func NewHub(bufferSize int, policy Policy, logger *slog.Logger) *Hub {
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+	if policy != Disconnect {
+		policy = DropOldest
+	}
+
+	return &Hub{
+		subs:       make(map[string]map[*Subscription]struct{}),
+		bufferSize: bufferSize,
+		policy:     policy,
+		logger:     logger.With("component", "event-hub"),
+		done:       make(chan struct{}),
+	}
+}
+
+// Close сообщает подписчикам через Done, что сервер останавливается.
+// Подписки завершают сами обработчики, отправив клиентам прощальное сообщение.
+func (h *Hub) Close() {
+	h.closeOnce.Do(func() {
+		h.logger.Info("Closing event hub", "lots", h.lotCount())
+		close(h.done)
+	})
+}
+
+// Done закрывается при остановке сервера.
+func (h *Hub) Done() <-chan struct{} {
+	return h.done
+}
+
+func (h *Hub) lotCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subs)
+}
+
+// userTopicPrefix отличает топики личных уведомлений от id лотов.
+const userTopicPrefix = "user:"
+
+// userTopic — топик личных уведомлений; префикс не даёт ему совпасть с id лота.
+func userTopic(userID string) string {
+	return userTopicPrefix + userID
+}
+
+func (h *Hub) Subscribe(lotID string) *Subscription {
+	return h.subscribe(lotID)
+}
+
+// SubscribeUser подписывает на личные уведомления пользователя.
+func (h *Hub) SubscribeUser(userID string) *Subscription {
+	return h.subscribe(userTopic(userID))
+}
+
+func (h *Hub) subscribe(topic string) *Subscription {
+	sub := &Subscription{
+		topic: topic,
+		ch:    make(chan Event, h.bufferSize),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.subs[topic] == nil {
+		h.subs[topic] = make(map[*Subscription]struct{})
+	}
+	h.subs[topic][sub] = struct{}{}
+
+	h.logger.Debug("Subscriber added", "topic", topic, "subscribers", len(h.subs[topic]))
+	return sub
+}
+
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	topicSubs, ok := h.subs[sub.topic]
+	if !ok {
+		return
+	}
+	if _, ok := topicSubs[sub]; !ok {
+		return
+	}
+
+	delete(topicSubs, sub)
+	if len(topicSubs) == 0 {
+		delete(h.subs, sub.topic)
+	}
+	// Отправка идёт только под RLock, поэтому закрывать канал под Lock безопасно
+	close(sub.ch)
+
+	h.logger.Debug("Subscriber removed", "topic", sub.topic, "subscribers", len(topicSubs))
+}
+
+func (h *Hub) Publish(event Event) {
+	h.publish(event.Lot.Id, event)
+}
+
+// Notify отправляет событие в личные уведомления пользователя. Если он
+// не подписан, событие теряется: хаб ничего не хранит.
+func (h *Hub) Notify(userID string, event Event) {
+	h.publish(userTopic(userID), event)
+}
+
+func (h *Hub) publish(topic string, event Event) {
+	var slow []*Subscription
+
+	h.mu.RLock()
+	for sub := range h.subs[topic] {
+		select {
+		case sub.ch <- event:
+			continue
+		default:
+		}
+
+		if h.policy == Disconnect {
+			slow = append(slow, sub)
+			continue
+		}
+
+		select {
+		case <-sub.ch:
+		default:
+		}
+		select {
+		case sub.ch <- event:
+		default:
+		}
+		h.logger.Debug("Dropped oldest event for slow subscriber", "topic", topic)
+	}
+	h.mu.RUnlock()
+
+	for _, sub := range slow {
+		h.logger.Warn("Disconnecting slow subscriber", "topic", topic)
+		h.Unsubscribe(sub)
+	}
+}
+
+// SubscriberTotal возвращает число всех подписок: на лоты и на личные уведомления.
+func (h *Hub) SubscriberTotal() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	total := 0
+	for _, topicSubs := range h.subs {
+		total += len(topicSubs)
+	}
+	return total
+}
+
+// LotSubscriberCounts возвращает число подписок по лотам, на которые сейчас
+// кто-то подписан; подписки на личные уведомления не учитываются.
+func (h *Hub) LotSubscriberCounts() map[string]int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	counts := make(map[string]int, len(h.subs))
+	for topic, topicSubs := range h.subs {
+		if strings.HasPrefix(topic, userTopicPrefix) {
+			continue
+		}
+		counts[topic] = len(topicSubs)
+	}
+	return counts
+}
@@ -0,0 +1,94 @@
+// Package metrics описывает метрики Prometheus сервиса. Все метрики
+// регистрируются в реестре по умолчанию и отдаются через Handler.
+package metrics
+
+import (
+	"net/http"
+
+	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "auction"
+
+// GRPCServer — метрики gRPC-вызовов: число вызовов по методу и коду ответа
+// и гистограмма длительности.
+var GRPCServer = grpcprom.NewServerMetrics(grpcprom.WithServerHandlingTimeHistogram())
+
+var (
+	// BidsTotal считает ставки по результату: accepted или rejected
+	// с причиной отказа (ErrorInfo.reason либо код gRPC).
+	BidsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bids_total",
+		Help:      "Bids processed, by result and rejection reason.",
+	}, []string{"result", "reason"})
+
+	LotsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "lots_created_total",
+		Help:      "Lots created.",
+	})
+
+	// LotsClosed считает лоты, перешедшие в конечный статус.
+	LotsClosed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "lots_closed_total",
+		Help:      "Lots that reached a terminal status, by status.",
+	}, []string{"status"})
+)
+
+func init() {
+	prometheus.MustRegister(GRPCServer)
+}
+
+// Handler отдаёт метрики в формате Prometheus.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// SubscriberCounter сообщает число активных подписок: всего и по лотам.
+type SubscriberCounter interface {
+	SubscriberTotal() int
+	LotSubscriberCounts() map[string]int
+}
+
+// subscriptionCollector снимает число подписок в момент опроса. Ряд с меткой
+// lot_id есть только у лотов, на которые сейчас кто-то подписан, поэтому
+// рядов не больше, чем открытых подписок, и ряд пропадает с последним подписчиком.
+type subscriptionCollector struct {
+	counter SubscriberCounter
+	total   *prometheus.Desc
+	perLot  *prometheus.Desc
+}
+
+// NewSubscriptionCollector возвращает коллектор числа активных подписок.
+func NewSubscriptionCollector(counter SubscriberCounter) prometheus.Collector {
+	return &subscriptionCollector{
+		counter: counter,
+		total: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "subscriptions_active"),
+			"Active subscriptions to lots and notifications.",
+			nil, nil,
+		),
+		perLot: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "lot_subscriptions_active"),
+			"Active subscriptions per lot, for lots that currently have subscribers.",
+			[]string{"lot_id"}, nil,
+		),
+	}
+}
+
+func (c *subscriptionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.total
+	ch <- c.perLot
+}
+
+func (c *subscriptionCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(c.counter.SubscriberTotal()))
+	for lotID, count := range c.counter.LotSubscriberCounts() {
+		ch <- prometheus.MustNewConstMetric(c.perLot, prometheus.GaugeValue, float64(count), lotID)
+	}
+}
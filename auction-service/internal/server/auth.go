@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Lemper29/auction-service/internal/auth"
+	"github.com/Lemper29/auction-service/internal/service"
+	"github.com/Lemper29/auction-service/pkg/models"
+	pb "github.com/Lemper29/auction/gen/auction"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// userIDMetadataKey — идентификатор пользователя, который api-gateway
+// передаёт после проверки токена.
+const userIDMetadataKey = "x-user-id"
+
+// methodRoles — роли, которым доступен метод. Методы, которых нет в списке,
+// доступны и анонимно. Проверки, зависящие от лота (владелец, ставка на
+// собственный лот), выполняет сервис.
+var methodRoles = map[string][]string{
+	pb.AuctionService_CreateLot_FullMethodName:  {models.RoleSeller, models.RoleAdmin},
+	pb.AuctionService_UpdateLot_FullMethodName:  {models.RoleSeller, models.RoleAdmin},
+	pb.AuctionService_CancelLot_FullMethodName:  {models.RoleSeller, models.RoleAdmin},
+	pb.AuctionService_PublishLot_FullMethodName: {models.RoleSeller, models.RoleAdmin},
+	pb.AuctionService_RelistLot_FullMethodName:  {models.RoleSeller, models.RoleAdmin},
+	pb.AuctionService_PlaceBid_FullMethodName:   {models.RoleBidder, models.RoleSeller, models.RoleAdmin},
+
+	pb.AuctionService_SubscribeToNotifications_FullMethodName: {models.RoleBidder, models.RoleSeller, models.RoleAdmin},
+}
+
+func (s *server) unaryAuthInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := s.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	if err := bindUserID(ctx, req); err != nil {
+		s.logger.WarnContext(ctx, "Spoofed user_id rejected", "method", info.FullMethod)
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// Потоковые методы получают запрос уже внутри обработчика, поэтому здесь
+// только аутентификация; user_id в них пока не передаётся.
+func (s *server) streamAuthInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.authenticate(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+}
+
+// contextStream подменяет контекст потока на дополненный интерцептором.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate проверяет токен из заголовка authorization и роль пользователя.
+// Без токена запрос проходит анонимно, если метод не требует аутентификации.
+func (s *server) authenticate(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	roles, restricted := methodRoles[method]
+
+	token := bearerToken(md)
+	if token == "" {
+		if restricted {
+			s.logger.WarnContext(ctx, "Unauthenticated call rejected", "method", method)
+			return nil, service.NewStatusError(codes.Unauthenticated, service.ReasonUnauthenticated,
+				"access token is required", nil)
+		}
+		return ctx, nil
+	}
+
+	identity, err := s.tokens.Parse(token)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Invalid access token", "method", method, "error", err)
+		return nil, service.NewStatusError(codes.Unauthenticated, service.ReasonInvalidToken,
+			auth.ErrInvalidToken.Error(), nil)
+	}
+
+	// Идентификатор, пересланный шлюзом, обязан совпадать с токеном
+	for _, forwarded := range md.Get(userIDMetadataKey) {
+		if forwarded != identity.UserID {
+			s.logger.WarnContext(ctx, "Forwarded identity mismatch", "method", method, "user_id", identity.UserID)
+			return nil, service.NewStatusError(codes.Unauthenticated, service.ReasonUserMismatch,
+				"forwarded identity does not match access token", nil)
+		}
+	}
+
+	if restricted && !identity.HasRole(roles...) {
+		s.logger.WarnContext(ctx, "Call rejected by role", "method", method, "user_id", identity.UserID, "role", identity.Role)
+		return nil, service.NewStatusError(codes.PermissionDenied, service.ReasonForbiddenRole,
+			"role "+identity.Role+" is not allowed to call this method", map[string]string{"role": identity.Role})
+	}
+
+	return auth.WithIdentity(ctx, identity), nil
+}
+
+func bearerToken(md metadata.MD) string {
+	for _, value := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(value, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
+
+// bindUserID подставляет аутентифицированного пользователя в поле user_id
+// запроса, если клиент его не указал, и отклоняет чужой идентификатор.
+func bindUserID(ctx context.Context, req any) error {
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	m := msg.ProtoReflect()
+	field := m.Descriptor().Fields().ByName("user_id")
+	if field == nil || field.Kind() != protoreflect.StringKind {
+		return nil
+	}
+
+	switch claimed := m.Get(field).String(); claimed {
+	case "":
+		m.Set(field, protoreflect.ValueOfString(identity.UserID))
+	case identity.UserID:
+	default:
+		return service.NewStatusError(codes.PermissionDenied, service.ReasonUserMismatch,
+			"user_id does not match authenticated user", map[string]string{"field": "user_id"})
+	}
+	return nil
+}
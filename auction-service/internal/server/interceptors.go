@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/Lemper29/auction-service/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Цепочка интерцепторов сервера, снаружи внутрь: метрики, идентификатор
+// запроса, журнал вызовов, восстановление после паники, дедлайн, аутентификация
+// и проверка запроса. Журнал стоит снаружи восстановления, чтобы вызов,
+// завершившийся паникой, попал в него с кодом Internal.
+
+func (s *server) unaryInterceptors() []grpc.UnaryServerInterceptor {
+	return []grpc.UnaryServerInterceptor{
+		metrics.GRPCServer.UnaryServerInterceptor(),
+		unaryRequestIDInterceptor,
+		s.unaryLoggingInterceptor,
+		s.unaryRecoveryInterceptor,
+		s.unaryDeadlineInterceptor,
+		s.unaryAuthInterceptor,
+		unaryValidationInterceptor,
+	}
+}
+
+func (s *server) streamInterceptors() []grpc.StreamServerInterceptor {
+	return []grpc.StreamServerInterceptor{
+		metrics.GRPCServer.StreamServerInterceptor(),
+		streamRequestIDInterceptor,
+		s.streamLoggingInterceptor,
+		s.streamRecoveryInterceptor,
+		s.streamAuthInterceptor,
+		streamValidationInterceptor,
+	}
+}
+
+func (s *server) unaryLoggingInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	s.logCall(ctx, info.FullMethod, req, start, err)
+	return resp, err
+}
+
+func (s *server) streamLoggingInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	s.logger.DebugContext(stream.Context(), "gRPC stream started", "method", info.FullMethod)
+	err := handler(srv, stream)
+	s.logCall(stream.Context(), info.FullMethod, nil, start, err)
+	return err
+}
+
+// logCall пишет одну запись о завершённом вызове. Уровень зависит от кода:
+// ошибки сервера — Error, отказы по состоянию и лимитам — Warn, остальное — Info.
+func (s *server) logCall(ctx context.Context, method string, req any, start time.Time, err error) {
+	code := status.Code(err)
+	level := callLogLevel(code)
+	// Пробы оркестратора приходят каждые несколько секунд и засоряли бы журнал
+	if strings.HasPrefix(method, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") && code == codes.OK {
+		level = slog.LevelDebug
+	}
+
+	attrs := []any{
+		"method", method,
+		"code", code.String(),
+		"duration", time.Since(start).String(),
+	}
+	if lotID := lotIDOf(req); lotID != "" {
+		attrs = append(attrs, "lot_id", lotID)
+	}
+	if err != nil {
+		attrs = append(attrs, "error", status.Convert(err).Message())
+	}
+	s.logger.Log(ctx, level, "gRPC call finished", attrs...)
+}
+
+func callLogLevel(code codes.Code) slog.Level {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unimplemented:
+		return slog.LevelError
+	case codes.DeadlineExceeded, codes.PermissionDenied, codes.ResourceExhausted,
+		codes.FailedPrecondition, codes.Aborted, codes.OutOfRange, codes.Unavailable:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func lotIDOf(req any) string {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return ""
+	}
+	m := msg.ProtoReflect()
+	field := m.Descriptor().Fields().ByName("lot_id")
+	if field == nil {
+		return ""
+	}
+	return m.Get(field).String()
+}
+
+// Паника в обработчике не должна ронять сервер вместе с остальными вызовами:
+// клиент получает Internal, стек остаётся только в логах.
+func (s *server) unaryRecoveryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = s.recovered(ctx, info.FullMethod, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func (s *server) streamRecoveryInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = s.recovered(stream.Context(), info.FullMethod, r)
+		}
+	}()
+	return handler(srv, stream)
+}
+
+func (s *server) recovered(ctx context.Context, method string, r any) error {
+	s.logger.ErrorContext(ctx, "Panic in gRPC handler",
+		"method", method,
+		"panic", fmt.Sprint(r),
+		"stack", string(debug.Stack()),
+	)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// unaryDeadlineInterceptor ограничивает вызовы без дедлайна, чтобы зависший
+// запрос к базе не держал соединение бесконечно. Дедлайн клиента не меняется.
+func (s *server) unaryDeadlineInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if _, ok := ctx.Deadline(); ok || s.callTimeout <= 0 {
+		return handler(ctx, req)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.callTimeout)
+	defer cancel()
+	return handler(ctx, req)
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"context"
+
+	"github.com/Lemper29/auction/pkg/logger"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// requestIDMetadataKey — идентификатор запроса, который api-gateway
+// принимает в заголовке X-Request-ID или создаёт сам.
+const requestIDMetadataKey = "x-request-id"
+
+func unaryRequestIDInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(withRequestID(ctx), req)
+}
+
+func streamRequestIDInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &contextStream{ServerStream: stream, ctx: withRequestID(stream.Context())})
+}
+
+// withRequestID кладёт идентификатор запроса в контекст, чтобы логгер добавлял
+// его к записям. Вызовам напрямую по gRPC, без шлюза, идентификатор создаётся здесь.
+func withRequestID(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(requestIDMetadataKey); len(ids) > 0 && logger.ValidRequestID(ids[0]) {
+		return logger.WithRequestID(ctx, ids[0])
+	}
+	return logger.WithRequestID(ctx, uuid.NewString())
+}
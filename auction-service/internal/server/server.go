@@ -4,29 +4,60 @@ import (
 	"context"
 	"log/slog"
 	"net"
+	"time"
 
+	"github.com/Lemper29/auction-service/internal/auth"
+	"github.com/Lemper29/auction-service/internal/events"
+	"github.com/Lemper29/auction-service/internal/metrics"
 	"github.com/Lemper29/auction-service/internal/service"
 	"github.com/Lemper29/auction-service/internal/storage"
+	"github.com/Lemper29/auction-service/pkg/models"
 	pb "github.com/Lemper29/auction/gen/auction"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type server struct {
 	pb.UnimplementedAuctionServiceServer
-	addr    string
-	service *service.LotService
-	logger  *slog.Logger
+	addr        string
+	callTimeout time.Duration
+	grpcServer  *grpc.Server
+	service     *service.LotService
+	users       *userServer
+	tokens      *auth.TokenManager
+	logger      *slog.Logger
 }
 
-func NewGrpcServer(addr string, storage storage.Storage, appLogger *slog.Logger) *server {
+func NewGrpcServer(addr string, storage storage.Storage, hub *events.Hub, defaultIncrement *models.IncrementRule, idempotencyTTL, callTimeout time.Duration, aliasKey []byte, tokens *auth.TokenManager, healthServer healthpb.HealthServer, appLogger *slog.Logger) *server {
 	serverLogger := appLogger.With("component", "grpc-server")
 
-	return &server{
-		addr:    addr,
-		service: service.NewLotService(storage, serverLogger),
-		logger:  serverLogger,
+	s := &server{
+		addr:        addr,
+		callTimeout: callTimeout,
+		service:     service.NewLotService(storage, hub, defaultIncrement, idempotencyTTL, aliasKey, serverLogger),
+		users: &userServer{
+			service: service.NewUserService(storage, tokens, serverLogger),
+			logger:  serverLogger,
+		},
+		tokens: tokens,
+		logger: serverLogger,
 	}
+
+	s.grpcServer = grpc.NewServer(
+		// Продолжает трассировку шлюза из traceparent; проверки здоровья не трассируются
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
+		grpc.ChainUnaryInterceptor(s.unaryInterceptors()...),
+		grpc.ChainStreamInterceptor(s.streamInterceptors()...),
+	)
+	pb.RegisterAuctionServiceServer(s.grpcServer, s)
+	pb.RegisterUserServiceServer(s.grpcServer, s.users)
+	healthpb.RegisterHealthServer(s.grpcServer, healthServer)
+	metrics.GRPCServer.InitializeMetrics(s.grpcServer)
+
+	return s
 }
 
 func (s *server) Start() error {
@@ -36,12 +67,9 @@ func (s *server) Start() error {
 		return err
 	}
 
-	grpcServer := grpc.NewServer()
-	pb.RegisterAuctionServiceServer(grpcServer, s)
-
 	s.logger.InfoContext(context.Background(), "Server starting", "address", s.addr)
 
-	if err := grpcServer.Serve(lis); err != nil {
+	if err := s.grpcServer.Serve(lis); err != nil {
 		s.logger.ErrorContext(context.Background(), "Server failed", "error", err)
 		return err
 	}
@@ -49,27 +77,65 @@ func (s *server) Start() error {
 	return nil
 }
 
+// Shutdown перестаёт принимать новые вызовы и ждёт завершения текущих.
+// Если ctx истекает раньше, оставшиеся вызовы прерываются.
+func (s *server) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+		return ctx.Err()
+	}
+}
+
 // Реализации gRPC методов
 func (s *server) CreateLot(ctx context.Context, req *pb.CreateLotRequest) (*pb.CreateLotResponse, error) {
-	s.logger.DebugContext(ctx, "CreateLot called", "name", req.Name)
 	return s.service.CreateLot(ctx, req)
 }
 
 func (s *server) GetLot(ctx context.Context, req *pb.GetLotRequest) (*pb.GetLotResponse, error) {
-	s.logger.DebugContext(ctx, "GetLot called", "lot_id", req.LotId)
 	return s.service.GetLot(ctx, req)
 }
 
+func (s *server) ListLots(ctx context.Context, req *pb.ListLotsRequest) (*pb.ListLotsResponse, error) {
+	return s.service.ListLots(ctx, req)
+}
+
+func (s *server) UpdateLot(ctx context.Context, req *pb.UpdateLotRequest) (*pb.UpdateLotResponse, error) {
+	return s.service.UpdateLot(ctx, req)
+}
+
+func (s *server) CancelLot(ctx context.Context, req *pb.CancelLotRequest) (*pb.CancelLotResponse, error) {
+	return s.service.CancelLot(ctx, req)
+}
+
+func (s *server) PublishLot(ctx context.Context, req *pb.PublishLotRequest) (*pb.PublishLotResponse, error) {
+	return s.service.PublishLot(ctx, req)
+}
+
+func (s *server) RelistLot(ctx context.Context, req *pb.RelistLotRequest) (*pb.RelistLotResponse, error) {
+	return s.service.RelistLot(ctx, req)
+}
+
 func (s *server) PlaceBid(ctx context.Context, req *pb.PlaceBidRequest) (*pb.PlaceBidResponse, error) {
-	s.logger.DebugContext(ctx, "PlaceBid called",
-		"lot_id", req.LotId,
-		"user_id", req.UserId,
-		"amount", req.Amount,
-	)
 	return s.service.PlaceBid(ctx, req)
 }
 
+func (s *server) ListBids(ctx context.Context, req *pb.ListBidsRequest) (*pb.ListBidsResponse, error) {
+	return s.service.ListBids(ctx, req)
+}
+
 func (s *server) SubscribeToLot(req *pb.SubscribeToLotRequest, stream pb.AuctionService_SubscribeToLotServer) error {
-	s.logger.InfoContext(stream.Context(), "SubscribeToLot called", "lot_id", req.LotId)
 	return s.service.SubscribeToLot(req, stream)
 }
+
+func (s *server) SubscribeToNotifications(req *pb.SubscribeToNotificationsRequest, stream pb.AuctionService_SubscribeToNotificationsServer) error {
+	return s.service.SubscribeToNotifications(req, stream)
+}
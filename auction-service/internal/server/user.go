@@ -0,0 +1,23 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Lemper29/auction-service/internal/service"
+	pb "github.com/Lemper29/auction/gen/auction"
+)
+
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+	service *service.UserService
+	logger  *slog.Logger
+}
+
+func (s *userServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	return s.service.Register(ctx, req)
+}
+
+func (s *userServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	return s.service.Login(ctx, req)
+}
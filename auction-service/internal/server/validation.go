@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"buf.build/go/protovalidate"
+	"github.com/Lemper29/auction-service/internal/service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Правила проверки описаны в auction.proto аннотациями buf.validate.
+// Правила, зависящие от состояния лота или денег, проверяет сервис.
+
+func unaryValidationInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// Потоковый обработчик читает запрос сам, поэтому проверяется каждое
+// полученное сообщение.
+func streamValidationInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &validatingStream{ServerStream: stream})
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validateRequest(m)
+}
+
+// validateRequest возвращает InvalidArgument со всеми нарушениями сразу:
+// ErrorInfo указывает на первое поле, BadRequest перечисляет каждое.
+func validateRequest(req any) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	err := protovalidate.Validate(msg)
+	if err == nil {
+		return nil
+	}
+	var verr *protovalidate.ValidationError
+	if !errors.As(err, &verr) {
+		// Ошибка компиляции правил — дефект схемы, а не запроса
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(verr.Violations))
+	messages := make([]string, 0, len(verr.Violations))
+	for _, v := range verr.Violations {
+		field := protovalidate.FieldPathString(v.Proto.GetField())
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: v.Proto.GetMessage(),
+		})
+		messages = append(messages, field+": "+v.Proto.GetMessage())
+	}
+
+	return service.NewStatusError(codes.InvalidArgument, service.ReasonInvalidRequest,
+		"invalid request: "+strings.Join(messages, "; "),
+		map[string]string{"field": violations[0].Field},
+		&errdetails.BadRequest{FieldViolations: violations})
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Lemper29/auction-service/internal/events"
+	"github.com/Lemper29/auction-service/internal/storage"
+)
+
+// LotActivator периодически открывает торги по запланированным лотам,
+// время начала которых наступило, и сообщает об этом подписчикам.
+type LotActivator struct {
+	repo      storage.Storage
+	hub       *events.Hub
+	interval  time.Duration
+	batchSize int
+	heartbeat *Heartbeat
+	logger    *slog.Logger
+}
+
+func NewLotActivator(repo storage.Storage, hub *events.Hub, interval time.Duration, batchSize int, heartbeat *Heartbeat, logger *slog.Logger) *LotActivator {
+	// Как и в AuctionCloser, пакет нулевого размера зациклил бы activateScheduled
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	return &LotActivator{
+		repo:      repo,
+		hub:       hub,
+		interval:  interval,
+		batchSize: batchSize,
+		heartbeat: heartbeat,
+		logger:    logger.With("component", "lot-activator"),
+	}
+}
+
+func (a *LotActivator) Run(ctx context.Context) {
+	a.logger.InfoContext(ctx, "Lot activator started", "interval", a.interval.String())
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			a.activateScheduled(ctx)
+			a.heartbeat.Beat()
+		case <-ctx.Done():
+			a.logger.InfoContext(ctx, "Lot activator stopped")
+			return
+		}
+	}
+}
+
+func (a *LotActivator) activateScheduled(ctx context.Context) {
+	for {
+		lots, err := a.repo.ActivateScheduledLots(ctx, time.Now(), a.batchSize)
+		if err != nil {
+			a.logger.ErrorContext(ctx, "Failed to activate scheduled lots", "error", err)
+			return
+		}
+
+		for _, lot := range lots {
+			a.logger.InfoContext(ctx, "Auction started",
+				"lot_id", lot.Id,
+				"start_time_unix", lot.StartTimeUnix,
+				"end_time_unix", lot.EndTimeUnix,
+			)
+			a.hub.Publish(events.Event{Type: events.LotStatusChanged, Lot: lot})
+		}
+
+		if len(lots) < a.batchSize {
+			return
+		}
+	}
+}
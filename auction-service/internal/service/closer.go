@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Lemper29/auction-service/internal/events"
+	"github.com/Lemper29/auction-service/internal/metrics"
+	"github.com/Lemper29/auction-service/internal/storage"
+)
+
+// AuctionCloser периодически завершает лоты, срок которых истёк,
+// и рассылает подписчикам итоговое состояние.
+type AuctionCloser struct {
+	repo      storage.Storage
+	hub       *events.Hub
+	interval  time.Duration
+	batchSize int
+	heartbeat *Heartbeat
+	logger    *slog.Logger
+}
+
+func NewAuctionCloser(repo storage.Storage, hub *events.Hub, interval time.Duration, batchSize int, heartbeat *Heartbeat, logger *slog.Logger) *AuctionCloser {
+	// closeExpired повторяет выборку, пока пакет заполнен целиком; при
+	// нулевом размере пакета это условие выполняется всегда
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	return &AuctionCloser{
+		repo:      repo,
+		hub:       hub,
+		interval:  interval,
+		batchSize: batchSize,
+		heartbeat: heartbeat,
+		logger:    logger.With("component", "auction-closer"),
+	}
+}
+
+func (c *AuctionCloser) Run(ctx context.Context) {
+	c.logger.InfoContext(ctx, "Auction closer started", "interval", c.interval.String())
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.closeExpired(ctx)
+			c.heartbeat.Beat()
+		case <-ctx.Done():
+			c.logger.InfoContext(ctx, "Auction closer stopped")
+			return
+		}
+	}
+}
+
+func (c *AuctionCloser) closeExpired(ctx context.Context) {
+	for {
+		lots, err := c.repo.CloseExpiredLots(ctx, time.Now(), c.batchSize)
+		if err != nil {
+			c.logger.ErrorContext(ctx, "Failed to close expired lots", "error", err)
+			return
+		}
+
+		for _, lot := range lots {
+			c.logger.InfoContext(ctx, "Auction closed",
+				"lot_id", lot.Id,
+				"status", lot.Status,
+				"winner", lot.CurrentWinner,
+				"final_price", lot.CurrentPrice,
+			)
+			metrics.LotsClosed.WithLabelValues(string(lot.Status)).Inc()
+			c.hub.Publish(events.Event{Type: events.LotClosed, Lot: lot})
+		}
+
+		if len(lots) < c.batchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Lemper29/auction-service/internal/storage"
+	"github.com/Lemper29/auction-service/pkg/models"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Ошибки правил управления лотом, которые проверяет сам сервис.
+var (
+	ErrNotLotOwner = errors.New("only the seller or an admin can manage this lot")
+	ErrLotHasBids  = errors.New("lot already has bids")
+)
+
+// Ошибки повторных запросов с ключом идемпотентности.
+var (
+	ErrIdempotencyKeyInUse  = errors.New("request with this idempotency key is still in progress")
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used with a different request")
+)
+
+// errorDomain — значение ErrorInfo.domain для всех ошибок сервиса.
+const errorDomain = "auction-service"
+
+// Машиночитаемые причины ошибок (ErrorInfo.reason). Клиенты ветвятся по ним,
+// поэтому менять существующие значения нельзя.
+const (
+	ReasonLotNotFound         = "LOT_NOT_FOUND"
+	ReasonLotNotActive        = "LOT_NOT_ACTIVE"
+	ReasonAuctionEnded        = "AUCTION_ENDED"
+	ReasonCurrencyMismatch    = "CURRENCY_MISMATCH"
+	ReasonAmountAboveMax      = "AMOUNT_ABOVE_MAX"
+	ReasonBidTooLow           = "BID_TOO_LOW"
+	ReasonInvalidMoney        = "INVALID_MONEY"
+	ReasonInvalidBidIncrement = "INVALID_BID_INCREMENT"
+	ReasonInvalidSort         = "INVALID_SORT"
+	ReasonInvalidPageToken    = "INVALID_PAGE_TOKEN"
+	ReasonSubscriberTooSlow   = "SUBSCRIBER_TOO_SLOW"
+	ReasonServerGoingAway     = "SERVER_GOING_AWAY"
+	ReasonUsernameTaken       = "USERNAME_TAKEN"
+	ReasonInvalidCredentials  = "INVALID_CREDENTIALS"
+	ReasonInvalidUser         = "INVALID_USER"
+	ReasonUnauthenticated     = "UNAUTHENTICATED"
+	ReasonInvalidToken        = "INVALID_TOKEN"
+	ReasonUserMismatch        = "USER_MISMATCH"
+	ReasonForbiddenRole       = "FORBIDDEN_ROLE"
+	ReasonSelfBidding         = "SELF_BIDDING"
+	ReasonNotLotOwner         = "NOT_LOT_OWNER"
+	ReasonInvalidTransition   = "INVALID_TRANSITION"
+	ReasonLotHasBids          = "LOT_HAS_BIDS"
+	ReasonAlreadyRelisted     = "ALREADY_RELISTED"
+	ReasonInvalidUpdateMask   = "INVALID_UPDATE_MASK"
+	ReasonInvalidLotField     = "INVALID_LOT_FIELD"
+	ReasonInvalidStatus       = "INVALID_STATUS"
+	ReasonInvalidRequest      = "INVALID_REQUEST"
+	ReasonInvalidIdempotency  = "INVALID_IDEMPOTENCY_KEY"
+	ReasonIdempotencyInUse    = "IDEMPOTENCY_KEY_IN_USE"
+	ReasonIdempotencyReused   = "IDEMPOTENCY_KEY_REUSED"
+)
+
+var domainErrors = []struct {
+	err    error
+	code   codes.Code
+	reason string
+}{
+	{storage.ErrLotNotFound, codes.NotFound, ReasonLotNotFound},
+	{storage.ErrLotNotActive, codes.FailedPrecondition, ReasonLotNotActive},
+	{storage.ErrAuctionEnded, codes.FailedPrecondition, ReasonAuctionEnded},
+	{storage.ErrBidTooLow, codes.FailedPrecondition, ReasonBidTooLow},
+	{storage.ErrSelfBidding, codes.PermissionDenied, ReasonSelfBidding},
+	{storage.ErrCurrencyMismatch, codes.InvalidArgument, ReasonCurrencyMismatch},
+	{storage.ErrAmountAboveMax, codes.InvalidArgument, ReasonAmountAboveMax},
+	{storage.ErrInvalidSort, codes.InvalidArgument, ReasonInvalidSort},
+	{storage.ErrInvalidPageToken, codes.InvalidArgument, ReasonInvalidPageToken},
+	{models.ErrInvalidIncrement, codes.InvalidArgument, ReasonInvalidBidIncrement},
+	{storage.ErrUsernameTaken, codes.AlreadyExists, ReasonUsernameTaken},
+	{storage.ErrAlreadyRelisted, codes.AlreadyExists, ReasonAlreadyRelisted},
+	{ErrNotLotOwner, codes.PermissionDenied, ReasonNotLotOwner},
+	{models.ErrInvalidTransition, codes.FailedPrecondition, ReasonInvalidTransition},
+	{ErrLotHasBids, codes.FailedPrecondition, ReasonLotHasBids},
+	{ErrIdempotencyKeyInUse, codes.Aborted, ReasonIdempotencyInUse},
+	{ErrIdempotencyKeyReused, codes.InvalidArgument, ReasonIdempotencyReused},
+}
+
+// NewStatusError строит gRPC-ошибку с ErrorInfo в домене сервиса. Детали
+// из extra добавляются после ErrorInfo.
+func NewStatusError(code codes.Code, reason, message string, metadata map[string]string, extra ...protoadapt.MessageV1) error {
+	st := status.New(code, message)
+	details := append([]protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   errorDomain,
+		Metadata: metadata,
+	}}, extra...)
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// toStatusError переводит ошибку хранилища в gRPC-статус. Доменные ошибки
+// получают свой код и причину, отмена контекста — соответствующий статус,
+// остальные скрываются за Internal: подробности остаются только в логах.
+func toStatusError(err error, metadata map[string]string) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	for _, de := range domainErrors {
+		if errors.Is(err, de.err) {
+			return NewStatusError(de.code, de.reason, err.Error(), metadata)
+		}
+	}
+
+	return status.Error(codes.Internal, "internal error")
+}
+
+// statusReason возвращает ErrorInfo.reason gRPC-ошибки, а если его нет — код.
+func statusReason(err error) string {
+	st := status.Convert(err)
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return st.Code().String()
+}
+
+// isDomainError сообщает, что ошибка ожидаема и вызвана запросом, а не сбоем.
+func isDomainError(err error) bool {
+	for _, de := range domainErrors {
+		if errors.Is(err, de.err) {
+			return true
+		}
+	}
+	return false
+}
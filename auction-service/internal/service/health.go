@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Lemper29/auction-service/internal/storage"
+	pb "github.com/Lemper29/auction/gen/auction"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthServices — сервисы, статус которых публикуется в grpc.health.v1.
+// Пустое имя означает состояние сервера в целом.
+var healthServices = []string{
+	"",
+	pb.AuctionService_ServiceDesc.ServiceName,
+	pb.UserService_ServiceDesc.ServiceName,
+}
+
+// Heartbeat отмечает, что фоновая задача продолжает работать. Задача
+// считается зависшей, если не отмечалась дольше maxAge. Нулевой *Heartbeat
+// ничего не отслеживает.
+type Heartbeat struct {
+	name   string
+	maxAge time.Duration
+	last   atomic.Int64
+}
+
+// Beat отмечает завершение очередного прохода задачи.
+func (h *Heartbeat) Beat() {
+	if h == nil {
+		return
+	}
+	h.last.Store(time.Now().UnixNano())
+}
+
+func (h *Heartbeat) stale(now time.Time) bool {
+	return now.Sub(time.Unix(0, h.last.Load())) > h.maxAge
+}
+
+// HealthMonitor периодически проверяет доступность базы и работу фоновых
+// задач и публикует итог в grpc.health.v1: SERVING, если всё в порядке,
+// иначе NOT_SERVING.
+type HealthMonitor struct {
+	repo         storage.Storage
+	health       *health.Server
+	interval     time.Duration
+	pingTimeout  time.Duration
+	stallTimeout time.Duration
+	logger       *slog.Logger
+
+	mu         sync.Mutex
+	heartbeats []*Heartbeat
+	serving    bool
+}
+
+func NewHealthMonitor(repo storage.Storage, healthServer *health.Server, interval, pingTimeout, stallTimeout time.Duration, logger *slog.Logger) *HealthMonitor {
+	// До первой проверки сервер не готов принимать трафик
+	for _, name := range healthServices {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+
+	return &HealthMonitor{
+		repo:         repo,
+		health:       healthServer,
+		interval:     interval,
+		pingTimeout:  pingTimeout,
+		stallTimeout: stallTimeout,
+		logger:       logger.With("component", "health-monitor"),
+	}
+}
+
+// Heartbeat регистрирует фоновую задачу с периодом interval. Задача должна
+// вызывать Beat после каждого прохода; проход может длиться до stallTimeout.
+func (m *HealthMonitor) Heartbeat(name string, interval time.Duration) *Heartbeat {
+	hb := &Heartbeat{name: name, maxAge: interval + m.stallTimeout}
+	hb.Beat()
+
+	m.mu.Lock()
+	m.heartbeats = append(m.heartbeats, hb)
+	m.mu.Unlock()
+
+	return hb
+}
+
+func (m *HealthMonitor) Run(ctx context.Context) {
+	m.logger.InfoContext(ctx, "Health monitor started", "interval", m.interval.String())
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	m.check(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			m.check(ctx)
+		case <-ctx.Done():
+			m.logger.InfoContext(ctx, "Health monitor stopped")
+			return
+		}
+	}
+}
+
+func (m *HealthMonitor) check(ctx context.Context) {
+	var problems []any
+
+	pingCtx, cancel := context.WithTimeout(ctx, m.pingTimeout)
+	err := m.repo.Ping(pingCtx)
+	cancel()
+	if err != nil {
+		problems = append(problems, "database", err.Error())
+	}
+
+	now := time.Now()
+	m.mu.Lock()
+	for _, hb := range m.heartbeats {
+		if hb.stale(now) {
+			problems = append(problems, "stalled_job", hb.name)
+		}
+	}
+	serving := len(problems) == 0
+	changed := serving != m.serving
+	m.serving = serving
+	m.mu.Unlock()
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if !serving {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	for _, name := range healthServices {
+		m.health.SetServingStatus(name, status)
+	}
+
+	// В лог попадают только смены состояния, а не каждая проверка
+	switch {
+	case changed && serving:
+		m.logger.InfoContext(ctx, "Service is healthy")
+	case changed:
+		m.logger.WarnContext(ctx, "Service is unhealthy", problems...)
+	}
+}
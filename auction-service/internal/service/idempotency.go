@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/Lemper29/auction-service/internal/auth"
+	"github.com/Lemper29/auction-service/internal/storage"
+	"github.com/Lemper29/auction-service/pkg/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// idempotencyKeyMetadata — заголовок Idempotency-Key, переданный api-gateway
+	idempotencyKeyMetadata = "idempotency-key"
+	idempotencyKeyField    = "idempotency_key"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// idempotent выполняет call не более одного раза для ключа идемпотентности
+// запроса. Повтор с тем же ключом получает сохранённый ответ первого вызова.
+// Сохраняется только успешный ответ: после ошибки запрос можно повторить
+// с тем же ключом. Запросы без ключа выполняются как обычно.
+func idempotent[T proto.Message](ctx context.Context, l *LotService, method string, req proto.Message, call func() (T, error)) (T, error) {
+	var zero T
+
+	key, err := idempotencyKey(ctx, req)
+	if err != nil {
+		return zero, err
+	}
+	if key == "" {
+		return call()
+	}
+
+	identity, _ := auth.FromContext(ctx)
+	now := time.Now()
+	record := &models.IdempotencyRecord{
+		// Ключи разных пользователей и методов не пересекаются
+		Key:         hashString(method + "\x00" + identity.UserID + "\x00" + key),
+		RequestHash: requestHash(req),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(l.idempotencyTTL),
+	}
+
+	existing, err := l.repo.ReserveIdempotencyKey(ctx, record)
+	if err != nil {
+		l.logger.ErrorContext(ctx, "Failed to reserve idempotency key", "method", method, "error", err)
+		return zero, toStatusError(err, nil)
+	}
+	if existing != nil {
+		switch {
+		case existing.RequestHash != record.RequestHash:
+			l.logger.WarnContext(ctx, "Idempotency key reused with different request", "method", method)
+			return zero, toStatusError(ErrIdempotencyKeyReused, nil)
+		case !existing.Completed:
+			l.logger.WarnContext(ctx, "Idempotent request still in progress", "method", method)
+			return zero, toStatusError(ErrIdempotencyKeyInUse, nil)
+		}
+
+		response := zero.ProtoReflect().Type().New().Interface().(T)
+		if err := proto.Unmarshal(existing.Response, response); err != nil {
+			l.logger.ErrorContext(ctx, "Failed to decode stored response", "method", method, "error", err)
+			return zero, toStatusError(err, nil)
+		}
+		l.logger.InfoContext(ctx, "Idempotent request replayed", "method", method)
+		return response, nil
+	}
+
+	// Запись ключа не должна сорваться из-за того, что клиент уже отключился
+	storeCtx := context.WithoutCancel(ctx)
+
+	response, err := call()
+	if err != nil {
+		if releaseErr := l.repo.ReleaseIdempotencyKey(storeCtx, record.Key); releaseErr != nil {
+			l.logger.ErrorContext(ctx, "Failed to release idempotency key", "method", method, "error", releaseErr)
+		}
+		return response, err
+	}
+
+	// Запрос уже выполнен, поэтому сбой сохранения ответа не превращается в ошибку:
+	// повтор с этим ключом получит IDEMPOTENCY_KEY_IN_USE до истечения блокировки
+	data, err := proto.Marshal(response)
+	if err == nil {
+		err = l.repo.CompleteIdempotencyKey(storeCtx, record.Key, data)
+	}
+	if err != nil {
+		l.logger.ErrorContext(ctx, "Failed to store idempotent response", "method", method, "error", err)
+	}
+
+	return response, nil
+}
+
+// idempotencyKey берёт ключ из заголовка или из поля idempotency_key запроса.
+// Если заданы оба, они должны совпадать.
+func idempotencyKey(ctx context.Context, req proto.Message) (string, error) {
+	fd := req.ProtoReflect().Descriptor().Fields().ByName(idempotencyKeyField)
+	if fd == nil {
+		return "", nil
+	}
+	key := req.ProtoReflect().Get(fd).String()
+
+	if values := metadata.ValueFromIncomingContext(ctx, idempotencyKeyMetadata); len(values) > 0 && values[0] != "" {
+		if key != "" && key != values[0] {
+			return "", NewStatusError(codes.InvalidArgument, ReasonInvalidIdempotency,
+				"Idempotency-Key header does not match idempotency_key field",
+				map[string]string{"field": idempotencyKeyField})
+		}
+		key = values[0]
+	}
+
+	if len(key) > maxIdempotencyKeyLength {
+		return "", NewStatusError(codes.InvalidArgument, ReasonInvalidIdempotency,
+			"idempotency key must be at most 255 bytes",
+			map[string]string{"field": idempotencyKeyField})
+	}
+	return key, nil
+}
+
+// requestHash — отпечаток запроса без ключа: повтор должен совпадать с оригиналом.
+func requestHash(req proto.Message) string {
+	clone := proto.Clone(req)
+	clone.ProtoReflect().Clear(clone.ProtoReflect().Descriptor().Fields().ByName(idempotencyKeyField))
+
+	data, _ := proto.MarshalOptions{Deterministic: true}.Marshal(clone)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// IdempotencyCleaner периодически удаляет истёкшие ключи идемпотентности.
+type IdempotencyCleaner struct {
+	repo      storage.Storage
+	interval  time.Duration
+	heartbeat *Heartbeat
+	logger    *slog.Logger
+}
+
+func NewIdempotencyCleaner(repo storage.Storage, interval time.Duration, heartbeat *Heartbeat, logger *slog.Logger) *IdempotencyCleaner {
+	return &IdempotencyCleaner{
+		repo:      repo,
+		interval:  interval,
+		heartbeat: heartbeat,
+		logger:    logger.With("component", "idempotency-cleaner"),
+	}
+}
+
+func (c *IdempotencyCleaner) Run(ctx context.Context) {
+	c.logger.InfoContext(ctx, "Idempotency cleaner started", "interval", c.interval.String())
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			deleted, err := c.repo.DeleteExpiredIdempotencyKeys(ctx, time.Now())
+			c.heartbeat.Beat()
+			if err != nil {
+				c.logger.ErrorContext(ctx, "Failed to delete expired idempotency keys", "error", err)
+				continue
+			}
+			if deleted > 0 {
+				c.logger.DebugContext(ctx, "Expired idempotency keys deleted", "count", deleted)
+			}
+		case <-ctx.Done():
+			c.logger.InfoContext(ctx, "Idempotency cleaner stopped")
+			return
+		}
+	}
+}
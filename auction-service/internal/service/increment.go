@@ -0,0 +1,40 @@
+package service
+
+import (
+	"github.com/Lemper29/auction-service/pkg/models"
+	pb "github.com/Lemper29/auction/gen/auction"
+)
+
+func fromPbBidIncrement(increment *pb.BidIncrement) *models.IncrementRule {
+	rule := &models.IncrementRule{
+		Type:       increment.Type,
+		Fixed:      increment.FixedMinorUnits,
+		PercentBps: increment.PercentBps,
+	}
+	for _, tier := range increment.Tiers {
+		rule.Tiers = append(rule.Tiers, models.IncrementTier{
+			From:      tier.FromMinorUnits,
+			Increment: tier.IncrementMinorUnits,
+		})
+	}
+	return rule
+}
+
+func toPbBidIncrement(rule *models.IncrementRule) *pb.BidIncrement {
+	if rule == nil {
+		return nil
+	}
+
+	increment := &pb.BidIncrement{
+		Type:            rule.Type,
+		FixedMinorUnits: rule.Fixed,
+		PercentBps:      rule.PercentBps,
+	}
+	for _, tier := range rule.Tiers {
+		increment.Tiers = append(increment.Tiers, &pb.BidIncrementTier{
+			FromMinorUnits:      tier.From,
+			IncrementMinorUnits: tier.Increment,
+		})
+	}
+	return increment
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Lemper29/auction-service/internal/auth"
+	"github.com/Lemper29/auction-service/internal/events"
+	"github.com/Lemper29/auction-service/internal/metrics"
+	"github.com/Lemper29/auction-service/internal/storage"
+	"github.com/Lemper29/auction-service/pkg/models"
+	pb "github.com/Lemper29/auction/gen/auction"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	actionUpdate  = "update"
+	actionCancel  = "cancel"
+	actionPublish = "publish"
+	actionRelist  = "relist"
+)
+
+// maxExtendMinutes ограничивает продление за один вызов UpdateLot, как
+// duration_minute ограничивает длительность нового лота.
+const maxExtendMinutes = 10000
+
+// allowedFrom — статусы, в которых допустимо действие над лотом. Отмену
+// проверяет только models.Lot.TransitionTo: она возможна из любого статуса,
+// откуда есть переход в CANCELLED.
+var allowedFrom = map[string][]models.LotStatus{
+	actionUpdate:  {models.StatusDraft, models.StatusScheduled, models.StatusActive},
+	actionPublish: {models.StatusDraft},
+	actionRelist:  {models.StatusUnsold, models.StatusCancelled},
+}
+
+// checkTransition проверяет, что identity может выполнить action над лотом
+// в его текущем состоянии.
+func checkTransition(identity auth.Identity, lot *models.Lot, action string, now time.Time) error {
+	if !identity.CanManageLot(lot) {
+		return ErrNotLotOwner
+	}
+	if from, ok := allowedFrom[action]; ok && !slices.Contains(from, lot.Status) {
+		return fmt.Errorf("%w: cannot %s lot in status %s", models.ErrInvalidTransition, action, lot.Status)
+	}
+
+	switch action {
+	case actionUpdate:
+		if lot.CurrentWinner != "" {
+			return ErrLotHasBids
+		}
+		fallthrough
+	case actionCancel:
+		// Истёкший лот, ещё не закрытый AuctionCloser, уже не изменить
+		if lot.Status == models.StatusActive && now.Unix() > lot.EndTimeUnix {
+			return fmt.Errorf("%w: cannot %s lot after its end time", models.ErrInvalidTransition, action)
+		}
+	}
+	return nil
+}
+
+func (l *LotService) UpdateLot(ctx context.Context, req *pb.UpdateLotRequest) (*pb.UpdateLotResponse, error) {
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		return nil, NewStatusError(codes.InvalidArgument, ReasonInvalidUpdateMask,
+			"update_mask is required", map[string]string{"field": "update_mask"})
+	}
+	for _, path := range paths {
+		switch path {
+		case "name":
+			if req.Name == "" {
+				return nil, NewStatusError(codes.InvalidArgument, ReasonInvalidLotField,
+					"name must not be empty", map[string]string{"field": "name"})
+			}
+		case "description":
+		case "extend_minutes":
+			if req.ExtendMinutes <= 0 {
+				return nil, NewStatusError(codes.InvalidArgument, ReasonInvalidLotField,
+					"extend_minutes must be positive", map[string]string{"field": "extend_minutes"})
+			}
+			if req.ExtendMinutes > maxExtendMinutes {
+				return nil, NewStatusError(codes.InvalidArgument, ReasonInvalidLotField,
+					fmt.Sprintf("extend_minutes must not exceed %d", maxExtendMinutes), map[string]string{"field": "extend_minutes"})
+			}
+		default:
+			return nil, NewStatusError(codes.InvalidArgument, ReasonInvalidUpdateMask,
+				fmt.Sprintf("field %q cannot be updated", path), map[string]string{"field": path})
+		}
+	}
+
+	l.logger.InfoContext(ctx, "Updating lot", "lot_id", req.LotId, "paths", paths)
+
+	identity, _ := auth.FromContext(ctx)
+	lot, err := l.repo.UpdateLot(ctx, req.LotId, func(lot *models.Lot) error {
+		now := time.Now()
+		if err := checkTransition(identity, lot, actionUpdate, now); err != nil {
+			return err
+		}
+
+		for _, path := range paths {
+			switch path {
+			case "name":
+				lot.Name = req.Name
+			case "description":
+				lot.Description = req.Description
+			case "extend_minutes":
+				lot.EndTimeUnix += req.ExtendMinutes * 60
+			}
+		}
+		lot.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return nil, l.lifecycleError(ctx, "update", req.LotId, err)
+	}
+
+	l.logger.InfoContext(ctx, "Lot updated", "lot_id", lot.Id, "end_time_unix", lot.EndTimeUnix)
+	l.hub.Publish(events.Event{Type: events.LotUpdated, Lot: *lot})
+
+	return &pb.UpdateLotResponse{Lot: convertToPbLot(lot)}, nil
+}
+
+func (l *LotService) CancelLot(ctx context.Context, req *pb.CancelLotRequest) (*pb.CancelLotResponse, error) {
+	l.logger.InfoContext(ctx, "Cancelling lot", "lot_id", req.LotId)
+
+	identity, _ := auth.FromContext(ctx)
+	lot, err := l.repo.UpdateLot(ctx, req.LotId, func(lot *models.Lot) error {
+		now := time.Now()
+		if err := checkTransition(identity, lot, actionCancel, now); err != nil {
+			return err
+		}
+
+		if err := lot.TransitionTo(models.StatusCancelled, now); err != nil {
+			return err
+		}
+		lot.CancelReason = req.Reason
+		return nil
+	})
+	if err != nil {
+		return nil, l.lifecycleError(ctx, "cancel", req.LotId, err)
+	}
+
+	l.logger.InfoContext(ctx, "Lot cancelled", "lot_id", lot.Id, "cancelled_by", identity.UserID)
+	metrics.LotsClosed.WithLabelValues(string(lot.Status)).Inc()
+	l.hub.Publish(events.Event{Type: events.LotCancelled, Lot: *lot})
+
+	// Лот уже отменён, поэтому сбой уведомления не должен превращаться в ошибку запроса
+	bidders, err := l.repo.ListBidders(ctx, lot.Id)
+	if err != nil {
+		l.logger.ErrorContext(ctx, "Failed to list bidders for notification", "lot_id", lot.Id, "error", err)
+	} else {
+		l.notifier.LotCancelled(ctx, *lot, bidders)
+	}
+
+	return &pb.CancelLotResponse{Lot: convertToPbLot(lot)}, nil
+}
+
+func (l *LotService) PublishLot(ctx context.Context, req *pb.PublishLotRequest) (*pb.PublishLotResponse, error) {
+	l.logger.InfoContext(ctx, "Publishing lot", "lot_id", req.LotId)
+
+	identity, _ := auth.FromContext(ctx)
+	lot, err := l.repo.UpdateLot(ctx, req.LotId, func(lot *models.Lot) error {
+		now := time.Now()
+		if err := checkTransition(identity, lot, actionPublish, now); err != nil {
+			return err
+		}
+		return storage.PublishLot(lot, now)
+	})
+	if err != nil {
+		return nil, l.lifecycleError(ctx, "publish", req.LotId, err)
+	}
+
+	l.logger.InfoContext(ctx, "Lot published", "lot_id", lot.Id, "status", lot.Status, "start_time_unix", lot.StartTimeUnix)
+	l.hub.Publish(events.Event{Type: events.LotStatusChanged, Lot: *lot})
+
+	return &pb.PublishLotResponse{Lot: convertToPbLot(lot)}, nil
+}
+
+func (l *LotService) RelistLot(ctx context.Context, req *pb.RelistLotRequest) (*pb.RelistLotResponse, error) {
+	res, err := l.repo.GetLot(ctx, &models.GetLotRequest{Lot_id: req.LotId})
+	if err != nil {
+		return nil, l.lifecycleError(ctx, "relist", req.LotId, err)
+	}
+	source := res.Lot
+
+	identity, _ := auth.FromContext(ctx)
+	if err := checkTransition(identity, &source, actionRelist, time.Now()); err != nil {
+		return nil, l.lifecycleError(ctx, "relist", req.LotId, err)
+	}
+
+	duration := req.DurationMinute
+	if duration == 0 {
+		duration = max((source.EndTimeUnix-source.StartTimeUnix)/60, 1)
+	}
+
+	l.logger.InfoContext(ctx, "Relisting lot", "lot_id", source.Id, "duration_minutes", duration)
+
+	// Продавец остаётся прежним, даже если лот выставляет администратор
+	createdLot, err := l.repo.CreateLot(ctx, &models.CreateLotRequest{
+		SellerID:       source.SellerID,
+		Name:           source.Name,
+		Description:    source.Description,
+		StartPrice:     source.Money(source.StartPrice),
+		DurationMinute: duration,
+
+		SoftCloseWindowMinutes:    source.SoftCloseWindowMinutes,
+		SoftCloseExtensionMinutes: source.SoftCloseExtensionMinutes,
+		ReservePrice:              source.Money(source.ReservePrice),
+		BuyNowPrice:               source.Money(source.BuyNowPrice),
+		BidIncrement:              source.BidIncrement,
+		RelistedFromID:            source.Id,
+	})
+	if err != nil {
+		return nil, l.lifecycleError(ctx, "relist", req.LotId, err)
+	}
+
+	l.logger.InfoContext(ctx, "Lot relisted", "lot_id", source.Id, "new_lot_id", createdLot.Id)
+	l.hub.Publish(events.Event{Type: events.LotCreated, Lot: *createdLot})
+
+	return &pb.RelistLotResponse{Lot: convertToPbLot(createdLot)}, nil
+}
+
+func (l *LotService) lifecycleError(ctx context.Context, action, lotID string, err error) error {
+	if isDomainError(err) {
+		l.logger.WarnContext(ctx, "Lot "+action+" rejected", "lot_id", lotID, "error", err)
+	} else {
+		l.logger.ErrorContext(ctx, "Failed to "+action+" lot", "lot_id", lotID, "error", err)
+	}
+	return toStatusError(err, map[string]string{"lot_id": lotID})
+}
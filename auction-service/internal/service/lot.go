@@ -2,46 +2,128 @@ package service
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"log/slog"
+	"strconv"
 	"time"
 
+	"github.com/Lemper29/auction-service/internal/auth"
+	"github.com/Lemper29/auction-service/internal/events"
+	"github.com/Lemper29/auction-service/internal/metrics"
 	"github.com/Lemper29/auction-service/internal/storage"
 	"github.com/Lemper29/auction-service/pkg/models"
+	"github.com/Lemper29/auction-service/pkg/money"
 	pb "github.com/Lemper29/auction/gen/auction"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
 )
 
 type LotService struct {
-	repo   storage.Storage
-	logger *slog.Logger
+	repo             storage.Storage
+	hub              *events.Hub
+	defaultIncrement *models.IncrementRule
+	idempotencyTTL   time.Duration
+	// Ключ HMAC для псевдонимов участников в ListBids
+	aliasKey []byte
+	notifier Notifier
+	logger   *slog.Logger
 }
 
-func NewLotService(repo storage.Storage, logger *slog.Logger) *LotService {
+func NewLotService(repo storage.Storage, hub *events.Hub, defaultIncrement *models.IncrementRule, idempotencyTTL time.Duration, aliasKey []byte, logger *slog.Logger) *LotService {
 	return &LotService{
-		repo:   repo,
-		logger: logger,
+		repo:             repo,
+		hub:              hub,
+		defaultIncrement: defaultIncrement,
+		idempotencyTTL:   idempotencyTTL,
+		aliasKey:         aliasKey,
+		notifier:         NewHubNotifier(hub, logger),
+		logger:           logger,
 	}
 }
 
 func (l *LotService) CreateLot(ctx context.Context, createLot *pb.CreateLotRequest) (*pb.CreateLotResponse, error) {
+	return idempotent(ctx, l, "CreateLot", createLot, func() (*pb.CreateLotResponse, error) {
+		return l.createLot(ctx, createLot)
+	})
+}
+
+func (l *LotService) createLot(ctx context.Context, createLot *pb.CreateLotRequest) (*pb.CreateLotResponse, error) {
+	startPrice, err := fromPbMoney("startPrice", createLot.StartPrice, money.DefaultCurrency)
+	if err != nil {
+		return nil, err
+	}
+	reservePrice, err := fromPbMoney("reserve_price", createLot.ReservePrice, startPrice.Currency)
+	if err != nil {
+		return nil, err
+	}
+	buyNowPrice, err := fromPbMoney("buy_now_price", createLot.BuyNowPrice, startPrice.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if err := sameCurrency(startPrice.Currency, map[string]money.Money{
+		"reserve_price": reservePrice,
+		"buy_now_price": buyNowPrice,
+	}); err != nil {
+		return nil, err
+	}
+	// Иначе первая же ставка продала бы лот ниже стартовой или резервной цены
+	if !buyNowPrice.IsZero() {
+		if buyNowPrice.Amount <= startPrice.Amount {
+			return nil, NewStatusError(codes.InvalidArgument, ReasonInvalidLotField,
+				"buy_now_price must be greater than startPrice", map[string]string{"field": "buy_now_price"})
+		}
+		if buyNowPrice.Amount < reservePrice.Amount {
+			return nil, NewStatusError(codes.InvalidArgument, ReasonInvalidLotField,
+				"buy_now_price must not be below reserve_price", map[string]string{"field": "buy_now_price"})
+		}
+	}
+	bidIncrement := l.defaultIncrement
+	if createLot.BidIncrement != nil {
+		bidIncrement = fromPbBidIncrement(createLot.BidIncrement)
+		if err := bidIncrement.Validate(); err != nil {
+			return nil, toStatusError(err, map[string]string{"field": "bid_increment"})
+		}
+	}
+
 	l.logger.InfoContext(ctx, "Creating lot",
 		"name", createLot.Name,
-		"start_price", createLot.StartPrice,
+		"start_price", startPrice.String(),
+		"start_time_unix", createLot.StartTimeUnix,
+		"draft", createLot.Draft,
 	)
 
+	identity, _ := auth.FromContext(ctx)
+
 	lot := &models.CreateLotRequest{
+		SellerID:       identity.UserID,
 		Name:           createLot.Name,
 		Description:    createLot.Description,
-		StartPrice:     createLot.StartPrice,
+		StartPrice:     startPrice,
 		DurationMinute: createLot.DurationMinute,
+
+		SoftCloseWindowMinutes:    createLot.SoftCloseWindowMinutes,
+		SoftCloseExtensionMinutes: createLot.SoftCloseExtensionMinutes,
+		ReservePrice:              reservePrice,
+		BuyNowPrice:               buyNowPrice,
+		BidIncrement:              bidIncrement,
+
+		StartTimeUnix: createLot.StartTimeUnix,
+		Draft:         createLot.Draft,
 	}
 
 	createdLot, err := l.repo.CreateLot(ctx, lot)
 	if err != nil {
 		l.logger.ErrorContext(ctx, "Failed to create lot", "error", err)
-		return nil, err
+		return nil, toStatusError(err, nil)
 	}
 
-	l.logger.InfoContext(ctx, "Lot created successfully", "lot_id", createdLot.Id)
+	l.logger.InfoContext(ctx, "Lot created successfully", "lot_id", createdLot.Id, "status", createdLot.Status)
+	metrics.LotsCreated.Inc()
+	l.hub.Publish(events.Event{Type: events.LotCreated, Lot: *createdLot})
+
 	return &pb.CreateLotResponse{
 		Lot: convertToPbLot(createdLot),
 	}, nil
@@ -56,8 +138,18 @@ func (l *LotService) GetLot(ctx context.Context, getLot *pb.GetLotRequest) (*pb.
 
 	res, err := l.repo.GetLot(ctx, lot)
 	if err != nil {
-		l.logger.ErrorContext(ctx, "Failed to get lot", "lot_id", getLot.LotId, "error", err)
-		return nil, err
+		if isDomainError(err) {
+			l.logger.WarnContext(ctx, "Lot not available", "lot_id", getLot.LotId, "error", err)
+		} else {
+			l.logger.ErrorContext(ctx, "Failed to get lot", "lot_id", getLot.LotId, "error", err)
+		}
+		return nil, toStatusError(err, map[string]string{"lot_id": getLot.LotId})
+	}
+
+	// Чужой черновик не раскрывается даже фактом существования
+	if identity, _ := auth.FromContext(ctx); res.Lot.Status == models.StatusDraft && !identity.CanManageLot(&res.Lot) {
+		l.logger.WarnContext(ctx, "Draft lot hidden from caller", "lot_id", getLot.LotId, "user_id", identity.UserID)
+		return nil, toStatusError(storage.ErrLotNotFound, map[string]string{"lot_id": getLot.LotId})
 	}
 
 	l.logger.DebugContext(ctx, "Lot retrieved", "lot_id", getLot.LotId)
@@ -66,99 +158,316 @@ func (l *LotService) GetLot(ctx context.Context, getLot *pb.GetLotRequest) (*pb.
 	}, nil
 }
 
+func (l *LotService) ListLots(ctx context.Context, listLots *pb.ListLotsRequest) (*pb.ListLotsResponse, error) {
+	minPrice, err := fromPbMoney("min_price", listLots.MinPrice, money.DefaultCurrency)
+	if err != nil {
+		return nil, err
+	}
+	maxPrice, err := fromPbMoney("max_price", listLots.MaxPrice, money.DefaultCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := fromPbLotStatus("status", listLots.Status)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []models.LotStatus
+	if status != "" {
+		statuses = []models.LotStatus{status}
+	} else {
+		// Старые клиенты передают статус строкой в legacy_status
+		statuses, err = fromLegacyLotStatus("legacy_status", listLots.LegacyStatus)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	identity, _ := auth.FromContext(ctx)
+
+	l.logger.DebugContext(ctx, "Listing lots",
+		"status", listLots.Status,
+		"query", listLots.Query,
+		"sort_by", listLots.SortBy,
+		"page_size", listLots.PageSize,
+	)
+
+	req := &models.ListLotsRequest{
+		Statuses:         statuses,
+		ViewerID:         identity.UserID,
+		ViewerAdmin:      identity.HasRole(models.RoleAdmin),
+		MinPrice:         minPrice,
+		MaxPrice:         maxPrice,
+		EndingAfterUnix:  listLots.EndingAfterUnix,
+		EndingBeforeUnix: listLots.EndingBeforeUnix,
+		Query:            listLots.Query,
+		SortBy:           listLots.SortBy,
+		Descending:       listLots.Descending,
+		PageSize:         int(listLots.PageSize),
+		PageToken:        listLots.PageToken,
+	}
+
+	res, err := l.repo.ListLots(ctx, req)
+	if err != nil {
+		if isDomainError(err) {
+			l.logger.WarnContext(ctx, "Invalid list lots request", "error", err)
+		} else {
+			l.logger.ErrorContext(ctx, "Failed to list lots", "error", err)
+		}
+		return nil, toStatusError(err, nil)
+	}
+
+	lots := make([]*pb.Lot, 0, len(res.Lots))
+	for i := range res.Lots {
+		lots = append(lots, convertToPbLot(&res.Lots[i]))
+	}
+
+	l.logger.DebugContext(ctx, "Lots listed", "count", len(lots), "has_next_page", res.NextPageToken != "")
+	return &pb.ListLotsResponse{
+		Lots:          lots,
+		NextPageToken: res.NextPageToken,
+	}, nil
+}
+
 func (l *LotService) PlaceBid(ctx context.Context, messagePlaceBid *pb.PlaceBidRequest) (*pb.PlaceBidResponse, error) {
+	return idempotent(ctx, l, "PlaceBid", messagePlaceBid, func() (*pb.PlaceBidResponse, error) {
+		res, err := l.placeBid(ctx, messagePlaceBid)
+		// Повторы по ключу идемпотентности сюда не доходят и не учитываются
+		if err != nil {
+			metrics.BidsTotal.WithLabelValues("rejected", statusReason(err)).Inc()
+		} else {
+			metrics.BidsTotal.WithLabelValues("accepted", "").Inc()
+		}
+		return res, err
+	})
+}
+
+func (l *LotService) placeBid(ctx context.Context, messagePlaceBid *pb.PlaceBidRequest) (*pb.PlaceBidResponse, error) {
+	amount, err := fromPbMoney("amount", messagePlaceBid.Amount, "")
+	if err != nil {
+		return nil, err
+	}
+	maxAmount, err := fromPbMoney("max_amount", messagePlaceBid.MaxAmount, amount.Currency)
+	if err != nil {
+		return nil, err
+	}
+
 	l.logger.InfoContext(ctx, "Processing bid",
 		"lot_id", messagePlaceBid.LotId,
 		"user_id", messagePlaceBid.UserId,
-		"amount", messagePlaceBid.Amount,
+		"amount", amount.String(),
+		"proxy", !maxAmount.IsZero(),
 	)
 
 	mes := &models.PlaceBidRequest{
-		Lot_id:  messagePlaceBid.LotId,
-		User_id: messagePlaceBid.UserId,
-		Amount:  messagePlaceBid.Amount,
+		Lot_id:     messagePlaceBid.LotId,
+		User_id:    messagePlaceBid.UserId,
+		Amount:     amount,
+		Max_amount: maxAmount,
 	}
 
 	res, err := l.repo.PlaceBid(ctx, mes)
 	if err != nil {
-		l.logger.ErrorContext(ctx, "Failed to process bid",
-			"lot_id", messagePlaceBid.LotId,
-			"error", err,
-		)
-		return nil, err
+		if !isDomainError(err) {
+			l.logger.ErrorContext(ctx, "Failed to process bid",
+				"lot_id", messagePlaceBid.LotId,
+				"error", err,
+			)
+			return nil, toStatusError(err, nil)
+		}
+
+		metadata := map[string]string{"lot_id": messagePlaceBid.LotId}
+		if res != nil {
+			l.logger.WarnContext(ctx, "Bid rejected",
+				"lot_id", messagePlaceBid.LotId,
+				"reason", err,
+				"current_price", res.Updated_lot.Money(res.Updated_lot.CurrentPrice).String(),
+			)
+			// Лот перешёл в CLOSING при проверке срока — подписчики узнают об этом сразу
+			if errors.Is(err, storage.ErrAuctionEnded) {
+				l.hub.Publish(events.Event{Type: events.LotStatusChanged, Lot: res.Updated_lot})
+			}
+			if errors.Is(err, storage.ErrBidTooLow) {
+				nextMinBid := res.Updated_lot.Money(res.Updated_lot.NextMinBid())
+				metadata["next_min_bid_minor_units"] = strconv.FormatInt(nextMinBid.Amount, 10)
+				metadata["currency_code"] = nextMinBid.Currency
+			}
+		}
+		return nil, toStatusError(err, metadata)
 	}
 
-	if res.Success {
-		l.logger.InfoContext(ctx, "Bid accepted",
-			"lot_id", messagePlaceBid.LotId,
-			"new_price", res.Updated_lot.CurrentPrice,
-			"winner", res.Updated_lot.CurrentWinner,
-		)
-	} else {
-		l.logger.WarnContext(ctx, "Bid rejected",
-			"lot_id", messagePlaceBid.LotId,
-			"reason", res.Message,
-			"current_price", res.Updated_lot.CurrentPrice,
-		)
+	l.logger.InfoContext(ctx, "Bid accepted",
+		"lot_id", messagePlaceBid.LotId,
+		"new_price", res.Updated_lot.Money(res.Updated_lot.CurrentPrice).String(),
+		"winner", res.Updated_lot.CurrentWinner,
+		"end_time_unix", res.Updated_lot.EndTimeUnix,
+	)
+	eventType := events.BidPlaced
+	if res.Updated_lot.Status.IsTerminal() {
+		eventType = events.LotClosed
+		metrics.LotsClosed.WithLabelValues(string(res.Updated_lot.Status)).Inc()
 	}
+	l.hub.Publish(events.Event{Type: eventType, Lot: res.Updated_lot})
 
 	return &pb.PlaceBidResponse{
-		Success:    res.Success,
+		Success:    true,
 		Message:    res.Message,
 		UpdatedLot: convertToPbLot(&res.Updated_lot),
 	}, nil
 }
 
+func (l *LotService) ListBids(ctx context.Context, listBids *pb.ListBidsRequest) (*pb.ListBidsResponse, error) {
+	l.logger.DebugContext(ctx, "Listing bids",
+		"lot_id", listBids.LotId,
+		"page_size", listBids.PageSize,
+		"mask_bidders", listBids.MaskBidders,
+	)
+
+	req := &models.ListBidsRequest{
+		Lot_id:    listBids.LotId,
+		PageSize:  int(listBids.PageSize),
+		PageToken: listBids.PageToken,
+	}
+
+	res, err := l.repo.ListBids(ctx, req)
+	if err != nil {
+		if isDomainError(err) {
+			l.logger.WarnContext(ctx, "Invalid list bids request", "lot_id", listBids.LotId, "error", err)
+		} else {
+			l.logger.ErrorContext(ctx, "Failed to list bids", "lot_id", listBids.LotId, "error", err)
+		}
+		return nil, toStatusError(err, map[string]string{"lot_id": listBids.LotId})
+	}
+
+	// Участников видят только продавец лота и администратор; флаг
+	// позволяет им самим получить публичное представление
+	identity, _ := auth.FromContext(ctx)
+	mask := listBids.MaskBidders || !identity.CanManageLot(&models.Lot{SellerID: res.SellerID})
+
+	bids := make([]*pb.Bid, 0, len(res.Bids))
+	for i := range res.Bids {
+		bid := convertToPbBid(&res.Bids[i], res.Currency)
+		if mask {
+			bid.UserId = l.maskBidder(bid.LotId, bid.UserId)
+		}
+		bids = append(bids, bid)
+	}
+
+	return &pb.ListBidsResponse{
+		Bids:          bids,
+		NextPageToken: res.NextPageToken,
+		TotalBids:     res.TotalBids,
+		UniqueBidders: res.UniqueBidders,
+	}, nil
+}
+
 func (l *LotService) SubscribeToLot(req *pb.SubscribeToLotRequest, stream pb.AuctionService_SubscribeToLotServer) error {
-	l.logger.InfoContext(stream.Context(), "Starting subscription", "lot_id", req.LotId)
+	ctx := stream.Context()
+	l.logger.InfoContext(ctx, "Starting subscription", "lot_id", req.LotId)
 
-	ticker := time.NewTicker(time.Second * 3)
-	defer ticker.Stop()
+	// Подписываемся до чтения снимка, чтобы не потерять ставки между ними
+	sub := l.hub.Subscribe(req.LotId)
+	defer l.hub.Unsubscribe(sub)
 
-	updateCount := 0
+	lotResponse, err := l.GetLot(ctx, &pb.GetLotRequest{LotId: req.LotId})
+	if err != nil {
+		l.logger.ErrorContext(ctx, "Failed to get lot for subscription",
+			"lot_id", req.LotId, "error", err)
+		return err
+	}
+
+	lot := lotResponse.Lot
+	if err := stream.Send(&pb.SubscribeToLotResponse{Lot: lot}); err != nil {
+		l.logger.ErrorContext(ctx, "Failed to send lot update",
+			"lot_id", req.LotId, "error", err)
+		return err
+	}
+	if isTerminalPbStatus(lot.Status) {
+		return nil
+	}
+
+	// Событие о закрытии или открытии лота приходит только в хаб реплики,
+	// где работал AuctionCloser или LotActivator, поэтому к этому времени
+	// подписка сама перечитывает лот из хранилища
+	recheck := time.NewTimer(nextLotRecheck(lot, time.Now()))
+	defer recheck.Stop()
+
+	updateCount := 1
 
 	for {
 		select {
-		case <-ticker.C:
-			lotResponse, err := l.GetLot(stream.Context(), &pb.GetLotRequest{LotId: req.LotId})
-			if err != nil {
-				l.logger.ErrorContext(stream.Context(), "Failed to get lot for subscription",
+		case event, ok := <-sub.Events():
+			if !ok {
+				l.logger.WarnContext(ctx, "Subscriber disconnected as too slow",
+					"lot_id", req.LotId,
+					"total_updates", updateCount,
+				)
+				return NewStatusError(codes.ResourceExhausted, ReasonSubscriberTooSlow,
+					"subscriber is too slow", map[string]string{"lot_id": req.LotId})
+			}
+
+			lot = convertToPbLot(&event.Lot)
+			if err := stream.Send(&pb.SubscribeToLotResponse{Lot: lot}); err != nil {
+				l.logger.ErrorContext(ctx, "Failed to send lot update",
 					"lot_id", req.LotId, "error", err)
 				return err
 			}
 
-			if lotResponse.Lot.EndTimeUnix <= time.Now().Unix() {
-				if lotResponse.Lot.Status == "ACTIVE" {
-					lotResponse.Lot.Status = "COMPLETED"
-					l.logger.InfoContext(stream.Context(), "Auction completed",
-						"lot_id", req.LotId,
-						"winner", lotResponse.Lot.CurrentWinner,
-						"final_price", lotResponse.Lot.CurrentPrice,
-					)
-
-					if err := stream.Send(&pb.SubscribeToLotResponse{Lot: lotResponse.Lot}); err != nil {
-						l.logger.ErrorContext(stream.Context(), "Failed to send completion update",
-							"lot_id", req.LotId, "error", err)
-					}
-					return nil
-				}
+			updateCount++
+			l.logger.DebugContext(ctx, "Sent lot update",
+				"lot_id", req.LotId,
+				"event", event.Type,
+				"update_count", updateCount,
+				"current_price", lot.CurrentPrice,
+			)
+
+			if event.Lot.Status.IsTerminal() {
+				return nil
 			}
+			recheck.Reset(nextLotRecheck(lot, time.Now()))
 
-			if err := stream.Send(&pb.SubscribeToLotResponse{Lot: lotResponse.Lot}); err != nil {
-				l.logger.ErrorContext(stream.Context(), "Failed to send lot update",
+		case <-recheck.C:
+			res, err := l.repo.GetLot(ctx, &models.GetLotRequest{Lot_id: req.LotId})
+			if err != nil {
+				l.logger.WarnContext(ctx, "Failed to re-read lot for subscription",
 					"lot_id", req.LotId, "error", err)
-				return err
+				recheck.Reset(lotRecheckInterval)
+				continue
 			}
 
-			updateCount++
-			l.logger.DebugContext(stream.Context(), "Sent lot update",
+			fresh := convertToPbLot(&res.Lot)
+			if !proto.Equal(fresh, lot) {
+				lot = fresh
+				if err := stream.Send(&pb.SubscribeToLotResponse{Lot: lot}); err != nil {
+					l.logger.ErrorContext(ctx, "Failed to send lot update",
+						"lot_id", req.LotId, "error", err)
+					return err
+				}
+
+				updateCount++
+				l.logger.DebugContext(ctx, "Sent re-read lot state",
+					"lot_id", req.LotId,
+					"status", lot.Status,
+					"update_count", updateCount,
+				)
+			}
+
+			if res.Lot.Status.IsTerminal() {
+				return nil
+			}
+			recheck.Reset(nextLotRecheck(lot, time.Now()))
+
+		// Клиент должен переподписаться, скорее всего уже к другой реплике
+		case <-l.hub.Done():
+			l.logger.InfoContext(ctx, "Subscription closed by server shutdown",
 				"lot_id", req.LotId,
-				"update_count", updateCount,
-				"current_price", lotResponse.Lot.CurrentPrice,
+				"total_updates", updateCount,
 			)
+			return NewStatusError(codes.Unavailable, ReasonServerGoingAway,
+				"server is going away, resubscribe", map[string]string{"lot_id": req.LotId})
 
-		case <-stream.Context().Done():
-			l.logger.InfoContext(stream.Context(), "Subscription ended by client",
+		case <-ctx.Done():
+			l.logger.InfoContext(ctx, "Subscription ended by client",
 				"lot_id", req.LotId,
 				"total_updates", updateCount,
 			)
@@ -167,19 +476,112 @@ func (l *LotService) SubscribeToLot(req *pb.SubscribeToLotRequest, stream pb.Auc
 	}
 }
 
+// lotRecheckInterval — как часто подписка перечитывает лот, время которого
+// вышло, пока его не закроет AuctionCloser на любой из реплик.
+const lotRecheckInterval = 2 * time.Second
+
+// nextLotRecheck возвращает, через сколько подписке перечитать лот: к началу
+// торгов запланированного лота, к окончанию торгов или, если оно прошло,
+// через lotRecheckInterval.
+func nextLotRecheck(lot *pb.Lot, now time.Time) time.Duration {
+	at := time.Unix(lot.EndTimeUnix, 0)
+	if lot.Status == pb.LotStatus_LOT_STATUS_SCHEDULED {
+		at = time.Unix(lot.StartTimeUnix, 0)
+	}
+	if d := at.Sub(now); d > 0 {
+		return d
+	}
+	return lotRecheckInterval
+}
+
+// SubscribeToNotifications передаёт личные уведомления пользователя из токена,
+// пока он не отпишется. Пропущенные без подписки уведомления не повторяются.
+func (l *LotService) SubscribeToNotifications(req *pb.SubscribeToNotificationsRequest, stream pb.AuctionService_SubscribeToNotificationsServer) error {
+	ctx := stream.Context()
+	identity, _ := auth.FromContext(ctx)
+	l.logger.InfoContext(ctx, "Starting notification subscription", "user_id", identity.UserID)
+
+	sub := l.hub.SubscribeUser(identity.UserID)
+	defer l.hub.Unsubscribe(sub)
+
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				l.logger.WarnContext(ctx, "Notification subscriber disconnected as too slow", "user_id", identity.UserID)
+				return NewStatusError(codes.ResourceExhausted, ReasonSubscriberTooSlow,
+					"subscriber is too slow", nil)
+			}
+
+			if err := stream.Send(&pb.SubscribeToNotificationsResponse{
+				Type: string(event.Type),
+				Lot:  convertToPbLot(&event.Lot),
+			}); err != nil {
+				l.logger.ErrorContext(ctx, "Failed to send notification",
+					"user_id", identity.UserID, "error", err)
+				return err
+			}
+			l.logger.DebugContext(ctx, "Sent notification",
+				"user_id", identity.UserID,
+				"event", event.Type,
+				"lot_id", event.Lot.Id,
+			)
+
+		case <-l.hub.Done():
+			l.logger.InfoContext(ctx, "Notification subscription closed by server shutdown", "user_id", identity.UserID)
+			return NewStatusError(codes.Unavailable, ReasonServerGoingAway,
+				"server is going away, resubscribe", nil)
+
+		case <-ctx.Done():
+			l.logger.InfoContext(ctx, "Notification subscription ended by client", "user_id", identity.UserID)
+			return nil
+		}
+	}
+}
+
 func convertToPbLot(lot *models.Lot) *pb.Lot {
 	if lot == nil {
 		return &pb.Lot{}
 	}
 
 	return &pb.Lot{
-		Id:            lot.Id,
-		Name:          lot.Name,
-		Description:   lot.Description,
-		StartPrice:    lot.StartPrice,
-		CurrentPrice:  lot.CurrentPrice,
-		CurrentWinner: lot.CurrentWinner,
-		Status:        lot.Status,
-		EndTimeUnix:   lot.EndTimeUnix,
+		Id:             lot.Id,
+		Name:           lot.Name,
+		Description:    lot.Description,
+		StartPrice:     toPbMoney(lot.Money(lot.StartPrice)),
+		CurrentPrice:   toPbMoney(lot.Money(lot.CurrentPrice)),
+		CurrentWinner:  lot.CurrentWinner,
+		SellerId:       lot.SellerID,
+		CancelReason:   lot.CancelReason,
+		RelistedFromId: lot.RelistedFromID,
+		Status:         toPbLotStatus(lot.Status),
+		StartTimeUnix:  lot.StartTimeUnix,
+		EndTimeUnix:    lot.EndTimeUnix,
+
+		SoftCloseWindowMinutes:    lot.SoftCloseWindowMinutes,
+		SoftCloseExtensionMinutes: lot.SoftCloseExtensionMinutes,
+		ReserveMet:                lot.ReserveMet(),
+		BuyNowPrice:               toPbMoney(lot.Money(lot.BuyNowPrice)),
+		BidIncrement:              toPbBidIncrement(lot.BidIncrement),
+		NextMinBid:                toPbMoney(lot.Money(lot.NextMinBid())),
 	}
 }
+
+func convertToPbBid(bid *models.Bid, currency string) *pb.Bid {
+	return &pb.Bid{
+		Id:            bid.ID,
+		LotId:         bid.LotId,
+		UserId:        bid.UserId,
+		Amount:        toPbMoney(money.New(bid.Amount, currency)),
+		TimestampUnix: bid.Timestamp_unix,
+	}
+}
+
+// maskBidder заменяет идентификатор участника псевдонимом, который постоянен
+// в пределах лота, но не позволяет связать ставки одного человека на разных лотах.
+// Без секретного ключа псевдоним можно было бы подобрать перебором известных id.
+func (l *LotService) maskBidder(lotID, userID string) string {
+	mac := hmac.New(sha256.New, l.aliasKey)
+	mac.Write([]byte(lotID + ":" + userID))
+	return "bidder-" + hex.EncodeToString(mac.Sum(nil)[:4])
+}
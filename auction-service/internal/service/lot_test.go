@@ -0,0 +1,672 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Lemper29/auction-service/internal/auth"
+	"github.com/Lemper29/auction-service/internal/events"
+	"github.com/Lemper29/auction-service/internal/storage/memory"
+	"github.com/Lemper29/auction-service/pkg/models"
+	pb "github.com/Lemper29/auction/gen/auction"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testSeller = "seller-1"
+	testAlice  = "bidder-alice"
+	testBob    = "bidder-bob"
+)
+
+type testEnv struct {
+	repo    *memory.MemoryStorage
+	hub     *events.Hub
+	service *LotService
+	logger  *slog.Logger
+}
+
+func asUser(userID, role string) context.Context {
+	return auth.WithIdentity(context.Background(), auth.Identity{UserID: userID, Role: role})
+}
+
+func rub(minorUnits int64) *pb.Money {
+	return &pb.Money{CurrencyCode: "RUB", MinorUnits: minorUnits}
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := memory.NewMemoryStorage()
+	hub := events.NewHub(16, events.DropOldest, logger)
+	increment := &models.IncrementRule{Type: models.IncrementFixed, Fixed: 100}
+
+	return &testEnv{
+		repo:    repo,
+		hub:     hub,
+		service: NewLotService(repo, hub, increment, time.Hour, []byte("test-alias-key"), logger),
+		logger:  logger,
+	}
+}
+
+// createLot создаёт лот от имени testSeller; req дополняется обязательными полями.
+func (e *testEnv) createLot(t *testing.T, req *pb.CreateLotRequest) *pb.Lot {
+	t.Helper()
+
+	if req.Name == "" {
+		req.Name = "lot"
+	}
+	if req.StartPrice == nil {
+		req.StartPrice = rub(1000)
+	}
+	if req.DurationMinute == 0 {
+		req.DurationMinute = 60
+	}
+
+	res, err := e.service.CreateLot(asUser(testSeller, models.RoleSeller), req)
+	if err != nil {
+		t.Fatalf("CreateLot: %v", err)
+	}
+	return res.Lot
+}
+
+func (e *testEnv) placeBid(userID, lotID string, amount, maxAmount int64) (*pb.PlaceBidResponse, error) {
+	req := &pb.PlaceBidRequest{LotId: lotID, UserId: userID}
+	if amount > 0 {
+		req.Amount = rub(amount)
+	}
+	if maxAmount > 0 {
+		req.MaxAmount = rub(maxAmount)
+	}
+	return e.service.PlaceBid(asUser(userID, models.RoleBidder), req)
+}
+
+func (e *testEnv) getLot(t *testing.T, lotID string) *pb.Lot {
+	t.Helper()
+
+	res, err := e.service.GetLot(context.Background(), &pb.GetLotRequest{LotId: lotID})
+	if err != nil {
+		t.Fatalf("GetLot: %v", err)
+	}
+	return res.Lot
+}
+
+// expire переносит окончание торгов в прошлое, как если бы срок лота истёк.
+func (e *testEnv) expire(t *testing.T, lotID string) {
+	t.Helper()
+
+	_, err := e.repo.UpdateLot(context.Background(), lotID, func(lot *models.Lot) error {
+		lot.EndTimeUnix = time.Now().Add(-time.Minute).Unix()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateLot: %v", err)
+	}
+}
+
+func assertReason(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+
+	if err == nil {
+		t.Fatalf("got no error, want %s %s", code, reason)
+	}
+	if got := status.Code(err); got != code {
+		t.Errorf("code = %s, want %s (%v)", got, code, err)
+	}
+	if got := statusReason(err); got != reason {
+		t.Errorf("reason = %s, want %s (%v)", got, reason, err)
+	}
+}
+
+func assertPrice(t *testing.T, lot *pb.Lot, want int64) {
+	t.Helper()
+
+	if got := lot.CurrentPrice.GetMinorUnits(); got != want {
+		t.Errorf("current price = %d, want %d", got, want)
+	}
+}
+
+func TestCreateLot(t *testing.T) {
+	env := newTestEnv(t)
+
+	lot := env.createLot(t, &pb.CreateLotRequest{Name: "Guitar", StartPrice: rub(1000)})
+
+	if lot.Status != pb.LotStatus_LOT_STATUS_ACTIVE {
+		t.Errorf("status = %s, want ACTIVE", lot.Status)
+	}
+	if lot.SellerId != testSeller {
+		t.Errorf("seller = %q, want %q", lot.SellerId, testSeller)
+	}
+	assertPrice(t, lot, 1000)
+	if end := time.Unix(lot.EndTimeUnix, 0); !end.After(time.Now()) {
+		t.Errorf("end time %s is not in the future", end)
+	}
+	if lot.BidIncrement.GetFixedMinorUnits() != 100 {
+		t.Errorf("bid increment = %v, want the default fixed 100", lot.BidIncrement)
+	}
+
+	draft := env.createLot(t, &pb.CreateLotRequest{Draft: true})
+	if draft.Status != pb.LotStatus_LOT_STATUS_DRAFT {
+		t.Errorf("draft status = %s, want DRAFT", draft.Status)
+	}
+}
+
+func TestCreateLotRejectsInvalidPrices(t *testing.T) {
+	tests := []struct {
+		name   string
+		req    *pb.CreateLotRequest
+		reason string
+		field  string
+	}{
+		{
+			name:   "buy now equal to start price",
+			req:    &pb.CreateLotRequest{StartPrice: rub(1000), BuyNowPrice: rub(1000)},
+			reason: ReasonInvalidLotField,
+			field:  "buy_now_price",
+		},
+		{
+			name:   "buy now below reserve price",
+			req:    &pb.CreateLotRequest{StartPrice: rub(1000), ReservePrice: rub(3000), BuyNowPrice: rub(2000)},
+			reason: ReasonInvalidLotField,
+			field:  "buy_now_price",
+		},
+		{
+			name:   "reserve in another currency",
+			req:    &pb.CreateLotRequest{StartPrice: rub(1000), ReservePrice: &pb.Money{CurrencyCode: "USD", MinorUnits: 3000}},
+			reason: ReasonCurrencyMismatch,
+			field:  "reserve_price",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			tt.req.Name = "lot"
+			tt.req.DurationMinute = 60
+
+			_, err := env.service.CreateLot(asUser(testSeller, models.RoleSeller), tt.req)
+			assertReason(t, err, codes.InvalidArgument, tt.reason)
+			if got := errorField(err); got != tt.field {
+				t.Errorf("field = %q, want %q", got, tt.field)
+			}
+		})
+	}
+}
+
+func errorField(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Metadata["field"]
+		}
+	}
+	return ""
+}
+
+func TestPlaceBid(t *testing.T) {
+	env := newTestEnv(t)
+	lot := env.createLot(t, &pb.CreateLotRequest{StartPrice: rub(1000)})
+
+	_, err := env.placeBid(testAlice, lot.Id, 1050, 0)
+	assertReason(t, err, codes.FailedPrecondition, ReasonBidTooLow)
+
+	_, err = env.placeBid(testSeller, lot.Id, 2000, 0)
+	assertReason(t, err, codes.PermissionDenied, ReasonSelfBidding)
+
+	res, err := env.placeBid(testAlice, lot.Id, 1100, 0)
+	if err != nil {
+		t.Fatalf("PlaceBid: %v", err)
+	}
+	if res.UpdatedLot.CurrentWinner != testAlice {
+		t.Errorf("winner = %q, want %q", res.UpdatedLot.CurrentWinner, testAlice)
+	}
+	assertPrice(t, res.UpdatedLot, 1100)
+
+	res, err = env.placeBid(testBob, lot.Id, 1500, 0)
+	if err != nil {
+		t.Fatalf("PlaceBid: %v", err)
+	}
+	if res.UpdatedLot.CurrentWinner != testBob {
+		t.Errorf("winner = %q, want %q", res.UpdatedLot.CurrentWinner, testBob)
+	}
+	assertPrice(t, env.getLot(t, lot.Id), 1500)
+}
+
+func TestPlaceBidProxy(t *testing.T) {
+	env := newTestEnv(t)
+	lot := env.createLot(t, &pb.CreateLotRequest{StartPrice: rub(1000)})
+
+	// Скрытый максимум не поднимает цену, пока нет конкурентов
+	res, err := env.placeBid(testAlice, lot.Id, 0, 5000)
+	if err != nil {
+		t.Fatalf("PlaceBid: %v", err)
+	}
+	assertPrice(t, res.UpdatedLot, 1100)
+
+	// Автоматическая ставка Алисы перебивает Боба на один шаг
+	res, err = env.placeBid(testBob, lot.Id, 3000, 0)
+	if err != nil {
+		t.Fatalf("PlaceBid: %v", err)
+	}
+	if res.UpdatedLot.CurrentWinner != testAlice {
+		t.Errorf("winner = %q, want %q", res.UpdatedLot.CurrentWinner, testAlice)
+	}
+	assertPrice(t, res.UpdatedLot, 3100)
+
+	// Боб перебивает максимум Алисы: цена — её максимум плюс шаг
+	res, err = env.placeBid(testBob, lot.Id, 0, 8000)
+	if err != nil {
+		t.Fatalf("PlaceBid: %v", err)
+	}
+	if res.UpdatedLot.CurrentWinner != testBob {
+		t.Errorf("winner = %q, want %q", res.UpdatedLot.CurrentWinner, testBob)
+	}
+	assertPrice(t, res.UpdatedLot, 5100)
+
+	_, err = env.placeBid(testAlice, lot.Id, 9000, 8500)
+	assertReason(t, err, codes.InvalidArgument, ReasonAmountAboveMax)
+}
+
+func TestPlaceBidReservePrice(t *testing.T) {
+	env := newTestEnv(t)
+	lot := env.createLot(t, &pb.CreateLotRequest{StartPrice: rub(1000), ReservePrice: rub(4000)})
+
+	// Максимум, покрывающий резерв, сразу поднимает цену до резерва
+	res, err := env.placeBid(testAlice, lot.Id, 0, 5000)
+	if err != nil {
+		t.Fatalf("PlaceBid: %v", err)
+	}
+	assertPrice(t, res.UpdatedLot, 4000)
+	if !res.UpdatedLot.ReserveMet {
+		t.Error("reserve is not met")
+	}
+
+	// Ставка ниже резерва принимается, но лот с ней не будет продан
+	other := env.createLot(t, &pb.CreateLotRequest{StartPrice: rub(1000), ReservePrice: rub(4000)})
+	res, err = env.placeBid(testBob, other.Id, 2000, 0)
+	if err != nil {
+		t.Fatalf("PlaceBid: %v", err)
+	}
+	assertPrice(t, res.UpdatedLot, 2000)
+	if res.UpdatedLot.ReserveMet {
+		t.Error("reserve is met below the reserve price")
+	}
+}
+
+func TestPlaceBidBuyNow(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    int64
+		maxAmount int64
+	}{
+		{name: "explicit amount", amount: 5000},
+		{name: "proxy maximum", maxAmount: 6000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			lot := env.createLot(t, &pb.CreateLotRequest{StartPrice: rub(1000), BuyNowPrice: rub(5000)})
+
+			res, err := env.placeBid(testAlice, lot.Id, tt.amount, tt.maxAmount)
+			if err != nil {
+				t.Fatalf("PlaceBid: %v", err)
+			}
+			if res.UpdatedLot.Status != pb.LotStatus_LOT_STATUS_SOLD {
+				t.Errorf("status = %s, want SOLD", res.UpdatedLot.Status)
+			}
+			if res.UpdatedLot.CurrentWinner != testAlice {
+				t.Errorf("winner = %q, want %q", res.UpdatedLot.CurrentWinner, testAlice)
+			}
+			assertPrice(t, res.UpdatedLot, 5000)
+
+			_, err = env.placeBid(testBob, lot.Id, 7000, 0)
+			assertReason(t, err, codes.FailedPrecondition, ReasonLotNotActive)
+		})
+	}
+}
+
+func TestPlaceBidPublishesEvent(t *testing.T) {
+	env := newTestEnv(t)
+	lot := env.createLot(t, &pb.CreateLotRequest{StartPrice: rub(1000)})
+
+	sub := env.hub.Subscribe(lot.Id)
+	defer env.hub.Unsubscribe(sub)
+
+	if _, err := env.placeBid(testAlice, lot.Id, 1100, 0); err != nil {
+		t.Fatalf("PlaceBid: %v", err)
+	}
+
+	select {
+	case event := <-sub.Events():
+		if event.Type != events.BidPlaced || event.Lot.CurrentWinner != testAlice {
+			t.Errorf("event = %s with winner %q, want %s with %q", event.Type, event.Lot.CurrentWinner, events.BidPlaced, testAlice)
+		}
+	default:
+		t.Error("subscribers were not notified about the bid")
+	}
+}
+
+func TestListBidsMasksBidders(t *testing.T) {
+	env := newTestEnv(t)
+	lot := env.createLot(t, &pb.CreateLotRequest{StartPrice: rub(1000)})
+	if _, err := env.placeBid(testAlice, lot.Id, 1100, 0); err != nil {
+		t.Fatalf("PlaceBid: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		mask   bool
+		masked bool
+	}{
+		{name: "anonymous", ctx: context.Background(), masked: true},
+		{name: "bidder", ctx: asUser(testAlice, models.RoleBidder), masked: true},
+		{name: "other seller", ctx: asUser("seller-2", models.RoleSeller), masked: true},
+		{name: "lot seller", ctx: asUser(testSeller, models.RoleSeller), masked: false},
+		{name: "lot seller asking for masking", ctx: asUser(testSeller, models.RoleSeller), mask: true, masked: true},
+		{name: "admin", ctx: asUser("admin-1", models.RoleAdmin), masked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := env.service.ListBids(tt.ctx, &pb.ListBidsRequest{LotId: lot.Id, MaskBidders: tt.mask})
+			if err != nil {
+				t.Fatalf("ListBids: %v", err)
+			}
+			if len(res.Bids) != 1 {
+				t.Fatalf("bids = %d, want 1", len(res.Bids))
+			}
+			if got := res.Bids[0].UserId == testAlice; got == tt.masked {
+				t.Errorf("bidder = %q, want masked = %v", res.Bids[0].UserId, tt.masked)
+			}
+		})
+	}
+}
+
+func TestGetLot(t *testing.T) {
+	env := newTestEnv(t)
+	lot := env.createLot(t, &pb.CreateLotRequest{Name: "Guitar"})
+
+	if got := env.getLot(t, lot.Id); got.Name != "Guitar" {
+		t.Errorf("name = %q, want %q", got.Name, "Guitar")
+	}
+
+	_, err := env.service.GetLot(context.Background(), &pb.GetLotRequest{LotId: "missing"})
+	assertReason(t, err, codes.NotFound, ReasonLotNotFound)
+}
+
+func TestListLots(t *testing.T) {
+	env := newTestEnv(t)
+	cheap := env.createLot(t, &pb.CreateLotRequest{Name: "cheap guitar", StartPrice: rub(1000)})
+	expensive := env.createLot(t, &pb.CreateLotRequest{Name: "expensive piano", StartPrice: rub(9000)})
+	draft := env.createLot(t, &pb.CreateLotRequest{Name: "draft guitar", Draft: true})
+
+	sold := env.createLot(t, &pb.CreateLotRequest{Name: "sold piano", StartPrice: rub(1000), BuyNowPrice: rub(2000)})
+	if _, err := env.placeBid(testAlice, sold.Id, 2000, 0); err != nil {
+		t.Fatalf("PlaceBid: %v", err)
+	}
+	unsold := env.createLot(t, &pb.CreateLotRequest{Name: "unsold piano", StartPrice: rub(1000)})
+	env.expire(t, unsold.Id)
+	NewAuctionCloser(env.repo, env.hub, time.Second, 10, nil, env.logger).closeExpired(context.Background())
+
+	tests := []struct {
+		name string
+		req  *pb.ListLotsRequest
+		want []string
+	}{
+		{
+			name: "by status",
+			req:  &pb.ListLotsRequest{Status: pb.LotStatus_LOT_STATUS_DRAFT},
+			want: []string{draft.Id},
+		},
+		{
+			name: "by legacy status",
+			req:  &pb.ListLotsRequest{LegacyStatus: "draft"},
+			want: []string{draft.Id},
+		},
+		{
+			// Прежний COMPLETED стал SOLD или UNSOLD в зависимости от победителя
+			name: "by legacy completed status",
+			req:  &pb.ListLotsRequest{LegacyStatus: "completed"},
+			want: []string{unsold.Id, sold.Id},
+		},
+		{
+			name: "by price and query",
+			req:  &pb.ListLotsRequest{Query: "guitar", MaxPrice: rub(5000), Status: pb.LotStatus_LOT_STATUS_ACTIVE},
+			want: []string{cheap.Id},
+		},
+		{
+			name: "sorted by price",
+			req:  &pb.ListLotsRequest{Status: pb.LotStatus_LOT_STATUS_ACTIVE, SortBy: "price", Descending: true},
+			want: []string{expensive.Id, cheap.Id},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := env.service.ListLots(asUser(testSeller, models.RoleSeller), tt.req)
+			if err != nil {
+				t.Fatalf("ListLots: %v", err)
+			}
+
+			got := make([]string, 0, len(res.Lots))
+			for _, lot := range res.Lots {
+				got = append(got, lot.Id)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("lots = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("lots = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	_, err := env.service.ListLots(context.Background(), &pb.ListLotsRequest{LegacyStatus: "bogus"})
+	assertReason(t, err, codes.InvalidArgument, ReasonInvalidStatus)
+}
+
+func TestDraftVisibility(t *testing.T) {
+	env := newTestEnv(t)
+	draft := env.createLot(t, &pb.CreateLotRequest{Draft: true})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		visible bool
+	}{
+		{name: "anonymous", ctx: context.Background()},
+		{name: "other seller", ctx: asUser("seller-2", models.RoleSeller)},
+		{name: "lot seller", ctx: asUser(testSeller, models.RoleSeller), visible: true},
+		{name: "admin", ctx: asUser("admin-1", models.RoleAdmin), visible: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := env.service.GetLot(tt.ctx, &pb.GetLotRequest{LotId: draft.Id})
+			if tt.visible && err != nil {
+				t.Errorf("GetLot: %v", err)
+			}
+			if !tt.visible {
+				assertReason(t, err, codes.NotFound, ReasonLotNotFound)
+			}
+
+			res, err := env.service.ListLots(tt.ctx, &pb.ListLotsRequest{})
+			if err != nil {
+				t.Fatalf("ListLots: %v", err)
+			}
+			if got := len(res.Lots) == 1; got != tt.visible {
+				t.Errorf("draft listed = %v, want %v", got, tt.visible)
+			}
+		})
+	}
+}
+
+func TestAuctionCloser(t *testing.T) {
+	env := newTestEnv(t)
+	sold := env.createLot(t, &pb.CreateLotRequest{StartPrice: rub(1000), ReservePrice: rub(2000)})
+	belowReserve := env.createLot(t, &pb.CreateLotRequest{StartPrice: rub(1000), ReservePrice: rub(5000)})
+	noBids := env.createLot(t, &pb.CreateLotRequest{StartPrice: rub(1000)})
+	running := env.createLot(t, &pb.CreateLotRequest{StartPrice: rub(1000)})
+
+	if _, err := env.placeBid(testAlice, sold.Id, 2500, 0); err != nil {
+		t.Fatalf("PlaceBid: %v", err)
+	}
+	if _, err := env.placeBid(testBob, belowReserve.Id, 3000, 0); err != nil {
+		t.Fatalf("PlaceBid: %v", err)
+	}
+	for _, id := range []string{sold.Id, belowReserve.Id, noBids.Id} {
+		env.expire(t, id)
+	}
+
+	sub := env.hub.Subscribe(sold.Id)
+	defer env.hub.Unsubscribe(sub)
+
+	closer := NewAuctionCloser(env.repo, env.hub, time.Second, 2, nil, env.logger)
+	closer.closeExpired(context.Background())
+
+	want := map[string]pb.LotStatus{
+		sold.Id:         pb.LotStatus_LOT_STATUS_SOLD,
+		belowReserve.Id: pb.LotStatus_LOT_STATUS_UNSOLD,
+		noBids.Id:       pb.LotStatus_LOT_STATUS_UNSOLD,
+		running.Id:      pb.LotStatus_LOT_STATUS_ACTIVE,
+	}
+	for id, status := range want {
+		if got := env.getLot(t, id).Status; got != status {
+			t.Errorf("lot %s status = %s, want %s", id, got, status)
+		}
+	}
+	if winner := env.getLot(t, sold.Id).CurrentWinner; winner != testAlice {
+		t.Errorf("winner = %q, want %q", winner, testAlice)
+	}
+
+	select {
+	case event := <-sub.Events():
+		if event.Type != events.LotClosed || event.Lot.Status != models.StatusSold {
+			t.Errorf("event = %s with status %s, want %s with SOLD", event.Type, event.Lot.Status, events.LotClosed)
+		}
+	default:
+		t.Error("subscribers were not notified about the closed lot")
+	}
+}
+
+func TestAuctionCloserNonPositiveBatchSize(t *testing.T) {
+	env := newTestEnv(t)
+	lot := env.createLot(t, &pb.CreateLotRequest{StartPrice: rub(1000)})
+	env.expire(t, lot.Id)
+
+	closer := NewAuctionCloser(env.repo, env.hub, time.Second, 0, nil, env.logger)
+
+	done := make(chan struct{})
+	go func() {
+		closer.closeExpired(context.Background())
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("closeExpired did not return with a zero batch size")
+	}
+	if got := env.getLot(t, lot.Id).Status; got != pb.LotStatus_LOT_STATUS_UNSOLD {
+		t.Errorf("status = %s, want UNSOLD", got)
+	}
+}
+
+func TestLotActivatorNonPositiveBatchSize(t *testing.T) {
+	env := newTestEnv(t)
+	lot := env.createLot(t, &pb.CreateLotRequest{StartTimeUnix: time.Now().Add(time.Hour).Unix()})
+	if lot.Status != pb.LotStatus_LOT_STATUS_SCHEDULED {
+		t.Fatalf("status = %s, want SCHEDULED", lot.Status)
+	}
+
+	_, err := env.repo.UpdateLot(context.Background(), lot.Id, func(lot *models.Lot) error {
+		lot.StartTimeUnix = time.Now().Add(-time.Minute).Unix()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateLot: %v", err)
+	}
+
+	activator := NewLotActivator(env.repo, env.hub, time.Second, 0, nil, env.logger)
+
+	done := make(chan struct{})
+	go func() {
+		activator.activateScheduled(context.Background())
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("activateScheduled did not return with a zero batch size")
+	}
+	if got := env.getLot(t, lot.Id).Status; got != pb.LotStatus_LOT_STATUS_ACTIVE {
+		t.Errorf("status = %s, want ACTIVE", got)
+	}
+}
+
+// lotStream — серверный поток SubscribeToLot, складывающий отправленные лоты в канал.
+type lotStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *pb.Lot
+}
+
+func (s *lotStream) Context() context.Context { return s.ctx }
+
+func (s *lotStream) Send(res *pb.SubscribeToLotResponse) error {
+	s.sent <- res.Lot
+	return nil
+}
+
+func TestSubscribeToLotSeesLotClosedByAnotherReplica(t *testing.T) {
+	env := newTestEnv(t)
+	lot := env.createLot(t, &pb.CreateLotRequest{})
+	env.expire(t, lot.Id)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &lotStream{ctx: ctx, sent: make(chan *pb.Lot, 4)}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- env.service.SubscribeToLot(&pb.SubscribeToLotRequest{LotId: lot.Id}, stream)
+	}()
+
+	if snapshot := <-stream.sent; snapshot.Status != pb.LotStatus_LOT_STATUS_ACTIVE {
+		t.Fatalf("snapshot status = %s, want ACTIVE", snapshot.Status)
+	}
+
+	// Закрывает лот другая реплика: хранилище общее, хаб свой
+	otherHub := events.NewHub(16, events.DropOldest, env.logger)
+	closer := NewAuctionCloser(env.repo, otherHub, time.Second, 10, nil, env.logger)
+	closer.closeExpired(context.Background())
+
+	timeout := time.After(3 * lotRecheckInterval)
+	select {
+	case update := <-stream.sent:
+		if update.Status != pb.LotStatus_LOT_STATUS_UNSOLD {
+			t.Errorf("update status = %s, want UNSOLD", update.Status)
+		}
+	case <-timeout:
+		t.Fatal("subscription did not pick up the lot closed by another replica")
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("SubscribeToLot: %v", err)
+		}
+	case <-timeout:
+		t.Fatal("subscription did not end after the lot was closed")
+	}
+}
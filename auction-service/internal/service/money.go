@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Lemper29/auction-service/pkg/money"
+	pb "github.com/Lemper29/auction/gen/auction"
+	"google.golang.org/grpc/codes"
+)
+
+// fromPbMoney переводит сумму из запроса. Пустая валюта заменяется на
+// defaultCurrency; если и она пуста, валюту определит хранилище по лоту.
+func fromPbMoney(field string, m *pb.Money, defaultCurrency string) (money.Money, error) {
+	if m == nil {
+		return money.Money{Currency: defaultCurrency}, nil
+	}
+
+	result := money.New(m.MinorUnits, m.CurrencyCode)
+	if result.Currency == "" {
+		result.Currency = defaultCurrency
+	}
+
+	if result.Currency == "" {
+		if result.Amount < 0 {
+			return money.Money{}, invalidMoney(field, money.ErrNegativeAmount)
+		}
+		return result, nil
+	}
+
+	if err := result.Validate(); err != nil {
+		return money.Money{}, invalidMoney(field, err)
+	}
+	return result, nil
+}
+
+func invalidMoney(field string, err error) error {
+	return NewStatusError(codes.InvalidArgument, ReasonInvalidMoney,
+		fmt.Sprintf("%s: %v", field, err), map[string]string{"field": field})
+}
+
+func toPbMoney(m money.Money) *pb.Money {
+	return &pb.Money{
+		CurrencyCode: m.Currency,
+		MinorUnits:   m.Amount,
+	}
+}
+
+func sameCurrency(currency string, fields map[string]money.Money) error {
+	for field, m := range fields {
+		if !m.IsZero() && m.Currency != currency {
+			return NewStatusError(codes.InvalidArgument, ReasonCurrencyMismatch,
+				fmt.Sprintf("%s: currency %s does not match lot currency %s", field, m.Currency, currency),
+				map[string]string{"field": field})
+		}
+	}
+	return nil
+}
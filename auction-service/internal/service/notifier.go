@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Lemper29/auction-service/internal/events"
+	"github.com/Lemper29/auction-service/pkg/models"
+)
+
+// Notifier доставляет участникам торгов уведомления, которые не зависят
+// от подписки на лот.
+type Notifier interface {
+	LotCancelled(ctx context.Context, lot models.Lot, bidderIDs []string)
+}
+
+// HubNotifier публикует уведомления в личные топики пользователей хаба,
+// откуда их получают подписчики SubscribeToNotifications.
+type HubNotifier struct {
+	hub    *events.Hub
+	logger *slog.Logger
+}
+
+func NewHubNotifier(hub *events.Hub, logger *slog.Logger) *HubNotifier {
+	return &HubNotifier{hub: hub, logger: logger}
+}
+
+func (n *HubNotifier) LotCancelled(ctx context.Context, lot models.Lot, bidderIDs []string) {
+	for _, bidderID := range bidderIDs {
+		n.hub.Notify(bidderID, events.Event{Type: events.LotCancelled, Lot: lot})
+		n.logger.DebugContext(ctx, "Notified bidder about cancelled lot",
+			"lot_id", lot.Id,
+			"user_id", bidderID,
+			"reason", lot.CancelReason,
+		)
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/Lemper29/auction-service/pkg/models"
+	pb "github.com/Lemper29/auction/gen/auction"
+	"google.golang.org/grpc/codes"
+)
+
+const pbStatusPrefix = "LOT_STATUS_"
+
+// fromPbLotStatus переводит фильтр статуса; UNSPECIFIED означает «любой».
+func fromPbLotStatus(field string, status pb.LotStatus) (models.LotStatus, error) {
+	if status == pb.LotStatus_LOT_STATUS_UNSPECIFIED {
+		return "", nil
+	}
+	if _, ok := pb.LotStatus_name[int32(status)]; !ok {
+		return "", NewStatusError(codes.InvalidArgument, ReasonInvalidStatus,
+			"unknown lot status", map[string]string{"field": field})
+	}
+	return models.LotStatus(strings.TrimPrefix(status.String(), pbStatusPrefix)), nil
+}
+
+// fromLegacyLotStatus переводит строковый фильтр ListLotsRequest.legacy_status.
+func fromLegacyLotStatus(field, status string) ([]models.LotStatus, error) {
+	if status == "" {
+		return nil, nil
+	}
+	parsed, ok := models.ParseLotStatus(status)
+	if !ok {
+		return nil, NewStatusError(codes.InvalidArgument, ReasonInvalidStatus,
+			"unknown lot status", map[string]string{"field": field})
+	}
+	return parsed, nil
+}
+
+func toPbLotStatus(status models.LotStatus) pb.LotStatus {
+	return pb.LotStatus(pb.LotStatus_value[pbStatusPrefix+string(status)])
+}
+
+func isTerminalPbStatus(status pb.LotStatus) bool {
+	s, err := fromPbLotStatus("status", status)
+	return err == nil && s.IsTerminal()
+}
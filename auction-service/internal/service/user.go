@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Lemper29/auction-service/internal/auth"
+	"github.com/Lemper29/auction-service/internal/storage"
+	"github.com/Lemper29/auction-service/pkg/models"
+	pb "github.com/Lemper29/auction/gen/auction"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	minPasswordLength = 8
+	// bcrypt учитывает только первые 72 байта пароля
+	maxPasswordLength = 72
+)
+
+var usernamePattern = regexp.MustCompile(`^[a-z0-9_.-]{3,32}$`)
+
+type UserService struct {
+	repo   storage.Storage
+	tokens *auth.TokenManager
+	logger *slog.Logger
+}
+
+func NewUserService(repo storage.Storage, tokens *auth.TokenManager, logger *slog.Logger) *UserService {
+	return &UserService{
+		repo:   repo,
+		tokens: tokens,
+		logger: logger,
+	}
+}
+
+func (u *UserService) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	username := normalizeUsername(req.Username)
+	if !usernamePattern.MatchString(username) {
+		return nil, NewStatusError(codes.InvalidArgument, ReasonInvalidUser,
+			"username must be 3-32 characters: latin letters, digits, '_', '.', '-'",
+			map[string]string{"field": "username"})
+	}
+	// Правила из auction.proto проверяет интерцептор, но роль определяет права,
+	// поэтому сервис не полагается на то, что его вызывают через него
+	if len(req.Password) < minPasswordLength || len(req.Password) > maxPasswordLength {
+		return nil, NewStatusError(codes.InvalidArgument, ReasonInvalidUser,
+			"password must be 8-72 bytes long",
+			map[string]string{"field": "password"})
+	}
+
+	role := req.Role
+	if role == "" {
+		role = models.RoleBidder
+	}
+	if role != models.RoleBidder && role != models.RoleSeller {
+		return nil, NewStatusError(codes.InvalidArgument, ReasonInvalidUser,
+			"role must be bidder or seller",
+			map[string]string{"field": "role"})
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		u.logger.ErrorContext(ctx, "Failed to hash password", "error", err)
+		return nil, toStatusError(err, nil)
+	}
+
+	user, err := u.repo.CreateUser(ctx, &models.CreateUserRequest{
+		Username:     username,
+		PasswordHash: string(hash),
+		Role:         role,
+	})
+	if err != nil {
+		if isDomainError(err) {
+			u.logger.WarnContext(ctx, "Registration rejected", "username", username, "error", err)
+		} else {
+			u.logger.ErrorContext(ctx, "Failed to create user", "username", username, "error", err)
+		}
+		return nil, toStatusError(err, map[string]string{"username": username})
+	}
+
+	u.logger.InfoContext(ctx, "User registered", "user_id", user.ID, "username", user.Username, "role", user.Role)
+	return &pb.RegisterResponse{User: convertToPbUser(user)}, nil
+}
+
+func (u *UserService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	username := normalizeUsername(req.Username)
+
+	// Неизвестный пользователь и неверный пароль неразличимы для клиента
+	invalidCredentials := NewStatusError(codes.Unauthenticated, ReasonInvalidCredentials,
+		"invalid username or password", nil)
+
+	user, err := u.repo.GetUserByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			u.logger.WarnContext(ctx, "Login failed", "username", username, "error", err)
+			return nil, invalidCredentials
+		}
+		u.logger.ErrorContext(ctx, "Failed to get user", "username", username, "error", err)
+		return nil, toStatusError(err, nil)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		u.logger.WarnContext(ctx, "Login failed", "username", username, "error", "password mismatch")
+		return nil, invalidCredentials
+	}
+
+	token, expiresAt, err := u.tokens.Issue(user, time.Now())
+	if err != nil {
+		u.logger.ErrorContext(ctx, "Failed to issue token", "user_id", user.ID, "error", err)
+		return nil, toStatusError(err, nil)
+	}
+
+	u.logger.InfoContext(ctx, "User logged in", "user_id", user.ID)
+	return &pb.LoginResponse{
+		AccessToken:   token,
+		ExpiresAtUnix: expiresAt.Unix(),
+		User:          convertToPbUser(user),
+	}, nil
+}
+
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+func convertToPbUser(user *models.User) *pb.User {
+	return &pb.User{
+		Id:            user.ID,
+		Username:      user.Username,
+		CreatedAtUnix: user.CreatedAt.Unix(),
+		Role:          user.Role,
+	}
+}
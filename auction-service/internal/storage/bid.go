@@ -0,0 +1,242 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Lemper29/auction-service/pkg/models"
+	"github.com/Lemper29/auction-service/pkg/money"
+	"github.com/google/uuid"
+)
+
+// ApplyBid применяет ставку к лоту по общим для всех хранилищ правилам.
+// leaderMax — скрытый максимум автоматической ставки текущего лидера (0, если его нет).
+// Если лот изменился (ставка принята или аукцион истёк), changed == true и
+// хранилище должно сохранить лот и все возвращённые записи ставок.
+// При отказе вместе с доменной ошибкой возвращается актуальное состояние лота.
+func ApplyBid(lot *models.Lot, placeBid *models.PlaceBidRequest, leaderMax int64, now time.Time) (bids []*models.Bid, response *models.PlaceBidResponse, changed bool, err error) {
+	rejected := &models.PlaceBidResponse{Updated_lot: *lot}
+
+	if lot.Status != models.StatusActive {
+		return nil, rejected, false, ErrLotNotActive
+	}
+
+	// Итог подведёт AuctionCloser, здесь лот только перестаёт принимать ставки
+	if now.Unix() > lot.EndTimeUnix {
+		if err := lot.TransitionTo(models.StatusClosing, now); err != nil {
+			return nil, rejected, false, err
+		}
+		return nil, &models.PlaceBidResponse{Updated_lot: *lot}, true, ErrAuctionEnded
+	}
+
+	if lot.SellerID != "" && placeBid.User_id == lot.SellerID {
+		return nil, rejected, false, ErrSelfBidding
+	}
+
+	for _, m := range []money.Money{placeBid.Amount, placeBid.Max_amount} {
+		if !m.IsZero() && m.Currency != "" && m.Currency != lot.Currency {
+			return nil, rejected, false, fmt.Errorf("%w: lot currency is %s", ErrCurrencyMismatch, lot.Currency)
+		}
+	}
+
+	amount := placeBid.Amount.Amount
+	proxyMax := placeBid.Max_amount.Amount
+
+	if proxyMax > 0 && amount > proxyMax {
+		return nil, rejected, false, ErrAmountAboveMax
+	}
+
+	maxAmount := proxyMax
+	if maxAmount == 0 {
+		maxAmount = amount
+	}
+
+	nextMinBid := lot.NextMinBid()
+	if maxAmount < nextMinBid {
+		return nil, rejected, false, fmt.Errorf("%w: minimum bid is %s", ErrBidTooLow, lot.Money(nextMinBid))
+	}
+
+	newBid := func(userID string, value, limit int64) *models.Bid {
+		return &models.Bid{
+			ID:             uuid.New().String(),
+			LotId:          lot.Id,
+			UserId:         userID,
+			Amount:         value,
+			MaxAmount:      limit,
+			Timestamp_unix: now.Unix(),
+			CreatedAt:      now,
+		}
+	}
+
+	leader := lot.CurrentWinner
+	prevPrice := lot.CurrentPrice
+	lot.UpdatedAt = now
+
+	// Мгновенная покупка срабатывает по максимуму участника, а не только по
+	// явной сумме: автоматическая ставка, готовая заплатить цену покупки,
+	// покупает лот сразу. Поэтому максимум лидера всегда ниже цены покупки,
+	// и покупка не может перескочить через его автоматическую ставку.
+	if lot.BuyNowPrice > 0 && maxAmount >= lot.BuyNowPrice {
+		lot.CurrentPrice = lot.BuyNowPrice
+		lot.CurrentWinner = placeBid.User_id
+		if err := lot.TransitionTo(models.StatusSold, now); err != nil {
+			return nil, rejected, false, err
+		}
+
+		return []*models.Bid{newBid(placeBid.User_id, lot.BuyNowPrice, proxyMax)}, &models.PlaceBidResponse{
+			Message:     "Лот куплен по цене мгновенной покупки",
+			Updated_lot: *lot,
+		}, true, nil
+	}
+
+	// Шаг считается от цены, которую перебивают
+	increment := lot.BidIncrement.Increment
+
+	bidAmount := amount
+	if bidAmount < nextMinBid {
+		bidAmount = min(maxAmount, nextMinBid)
+	}
+
+	message := "Ставка принята"
+	winnerMax := maxAmount
+
+	switch {
+	// Лидер только поднимает свой скрытый максимум
+	case leader == placeBid.User_id && amount == 0:
+		bids = append(bids, newBid(leader, lot.CurrentPrice, maxAmount))
+		message = "Максимальная ставка обновлена"
+
+	case leader == "" || leader == placeBid.User_id || leaderMax < bidAmount:
+		lot.CurrentPrice = bidAmount
+		lot.CurrentWinner = placeBid.User_id
+		bids = append(bids, newBid(placeBid.User_id, bidAmount, proxyMax))
+
+	// Автоматическая ставка лидера отвечает на новую; при равных максимумах
+	// побеждает тот, кто поставил раньше
+	case maxAmount <= leaderMax:
+		lot.CurrentPrice = min(leaderMax, maxAmount+increment(maxAmount))
+		bids = append(bids,
+			newBid(placeBid.User_id, maxAmount, proxyMax),
+			newBid(leader, lot.CurrentPrice, leaderMax),
+		)
+		message = "Ставка принята, но перебита автоматической ставкой лидера"
+		winnerMax = leaderMax
+
+	default:
+		lot.CurrentPrice = max(bidAmount, min(maxAmount, leaderMax+increment(leaderMax)))
+		lot.CurrentWinner = placeBid.User_id
+		bids = append(bids,
+			newBid(leader, leaderMax, leaderMax),
+			newBid(placeBid.User_id, lot.CurrentPrice, proxyMax),
+		)
+	}
+
+	// Как только максимум лидера покрывает резервную цену, цена поднимается до неё.
+	// Последняя запись всегда принадлежит лидеру.
+	if lot.ReservePrice > 0 && lot.CurrentPrice < lot.ReservePrice && winnerMax >= lot.ReservePrice {
+		lot.CurrentPrice = lot.ReservePrice
+		bids[len(bids)-1].Amount = lot.ReservePrice
+	}
+
+	if lot.CurrentPrice > prevPrice && extendSoftClose(lot, now) {
+		message += ", аукцион продлен"
+	}
+
+	return bids, &models.PlaceBidResponse{
+		Message:     message,
+		Updated_lot: *lot,
+	}, true, nil
+}
+
+// extendSoftClose продлевает аукцион, если ставка пришла в окне мягкого закрытия.
+func extendSoftClose(lot *models.Lot, now time.Time) bool {
+	if lot.SoftCloseWindowMinutes <= 0 || lot.SoftCloseExtensionMinutes <= 0 {
+		return false
+	}
+
+	window := lot.SoftCloseWindowMinutes * 60
+	if lot.EndTimeUnix-now.Unix() > window {
+		return false
+	}
+
+	lot.EndTimeUnix += lot.SoftCloseExtensionMinutes * 60
+	return true
+}
+
+// NewLot строит лот из запроса на создание. Лот открывается сразу, если
+// время начала не задано или уже прошло, иначе ждёт его в статусе SCHEDULED.
+func NewLot(createLot *models.CreateLotRequest, now time.Time) *models.Lot {
+	start := now
+	if createLot.StartTimeUnix > now.Unix() {
+		start = time.Unix(createLot.StartTimeUnix, 0)
+	}
+
+	status := models.StatusActive
+	switch {
+	case createLot.Draft:
+		status = models.StatusDraft
+	case start.After(now):
+		status = models.StatusScheduled
+	}
+
+	return &models.Lot{
+		Id:            uuid.New().String(),
+		Name:          createLot.Name,
+		Description:   createLot.Description,
+		Currency:      createLot.StartPrice.Currency,
+		StartPrice:    createLot.StartPrice.Amount,
+		CurrentPrice:  createLot.StartPrice.Amount,
+		CurrentWinner: "",
+		SellerID:      createLot.SellerID,
+		Status:        status,
+		StartTimeUnix: start.Unix(),
+		EndTimeUnix:   start.Add(time.Duration(createLot.DurationMinute) * time.Minute).Unix(),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+
+		SoftCloseWindowMinutes:    createLot.SoftCloseWindowMinutes,
+		SoftCloseExtensionMinutes: createLot.SoftCloseExtensionMinutes,
+		ReservePrice:              createLot.ReservePrice.Amount,
+		BuyNowPrice:               createLot.BuyNowPrice.Amount,
+		BidIncrement:              createLot.BidIncrement,
+		RelistedFromID:            createLot.RelistedFromID,
+	}
+}
+
+// PublishLot переводит черновик в SCHEDULED или, если время начала уже
+// наступило, сразу открывает торги.
+func PublishLot(lot *models.Lot, now time.Time) error {
+	if lot.StartTimeUnix > now.Unix() {
+		return lot.TransitionTo(models.StatusScheduled, now)
+	}
+	return ActivateLot(lot, now)
+}
+
+// ActivateLot открывает торги. Если лот открывается позже запланированного,
+// срок сдвигается, чтобы длительность торгов не сократилась.
+func ActivateLot(lot *models.Lot, now time.Time) error {
+	if err := lot.TransitionTo(models.StatusActive, now); err != nil {
+		return err
+	}
+	if late := now.Unix() - lot.StartTimeUnix; late > 0 {
+		lot.StartTimeUnix += late
+		lot.EndTimeUnix += late
+	}
+	return nil
+}
+
+// CloseLot подводит итог торгов: если есть победитель и резервная цена
+// достигнута, лот считается проданным по текущей цене, иначе — непроданным.
+// Активный лот сначала переводится в CLOSING.
+func CloseLot(lot *models.Lot, now time.Time) error {
+	if lot.Status == models.StatusActive {
+		if err := lot.TransitionTo(models.StatusClosing, now); err != nil {
+			return err
+		}
+	}
+
+	if lot.ReserveMet() {
+		return lot.TransitionTo(models.StatusSold, now)
+	}
+	return lot.TransitionTo(models.StatusUnsold, now)
+}
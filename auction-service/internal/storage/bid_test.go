@@ -0,0 +1,144 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/Lemper29/auction-service/internal/storage"
+	"github.com/Lemper29/auction-service/internal/storage/db"
+	"github.com/Lemper29/auction-service/internal/storage/memory"
+	"github.com/Lemper29/auction-service/pkg/models"
+	"github.com/Lemper29/auction-service/pkg/money"
+	"gorm.io/driver/postgres"
+)
+
+// testDSNEnv — строка подключения к PostgreSQL для тестов хранилища.
+// Без неё тесты на PostgreSQL пропускаются.
+const testDSNEnv = "TEST_DATABASE_DSN"
+
+// backends возвращает все хранилища, на которых должны выполняться тесты.
+func backends() map[string]func(t *testing.T) storage.Storage {
+	return map[string]func(t *testing.T) storage.Storage{
+		"memory": func(t *testing.T) storage.Storage {
+			return memory.NewMemoryStorage()
+		},
+		"postgres": func(t *testing.T) storage.Storage {
+			dsn := os.Getenv(testDSNEnv)
+			if dsn == "" {
+				t.Skipf("%s is not set", testDSNEnv)
+			}
+
+			pg, err := db.NewPostgresDB(postgres.Config{DSN: dsn, PreferSimpleProtocol: true})
+			if err != nil {
+				t.Fatalf("connect to postgres: %v", err)
+			}
+			t.Cleanup(func() { pg.Close() })
+
+			if err := pg.Migrate(context.Background()); err != nil {
+				t.Fatalf("migrate: %v", err)
+			}
+			return pg
+		},
+	}
+}
+
+func TestPlaceBidConcurrent(t *testing.T) {
+	const (
+		bidders    = 300
+		startPrice = 10_000
+	)
+
+	cases := []struct {
+		name string
+		// bid строит ставку участника на сумму amount
+		bid func(lotID, userID string, amount int64) *models.PlaceBidRequest
+		// Автоматическая ставка лидера может остановиться на шаг выше
+		// второго максимума, если тот пришёл раньше и был отклонён
+		minPrice int64
+	}{
+		{
+			name: "plain bids",
+			bid: func(lotID, userID string, amount int64) *models.PlaceBidRequest {
+				return &models.PlaceBidRequest{Lot_id: lotID, User_id: userID, Amount: money.New(amount, money.DefaultCurrency)}
+			},
+			minPrice: startPrice + bidders,
+		},
+		{
+			name: "proxy bids",
+			bid: func(lotID, userID string, amount int64) *models.PlaceBidRequest {
+				return &models.PlaceBidRequest{Lot_id: lotID, User_id: userID, Max_amount: money.New(amount, money.DefaultCurrency)}
+			},
+			minPrice: startPrice + bidders - 1,
+		},
+	}
+
+	for name, newStorage := range backends() {
+		for _, tc := range cases {
+			t.Run(name+"/"+tc.name, func(t *testing.T) {
+				repo := newStorage(t)
+				ctx := context.Background()
+
+				lot, err := repo.CreateLot(ctx, &models.CreateLotRequest{
+					Name:           "concurrent",
+					StartPrice:     money.New(startPrice, money.DefaultCurrency),
+					DurationMinute: 60,
+					BidIncrement:   &models.IncrementRule{Type: models.IncrementFixed, Fixed: 1},
+				})
+				if err != nil {
+					t.Fatalf("CreateLot: %v", err)
+				}
+
+				// Каждый участник ставит свою сумму; максимальная — у последнего
+				var (
+					wg       sync.WaitGroup
+					mu       sync.Mutex
+					accepted int
+				)
+				for i := 1; i <= bidders; i++ {
+					wg.Add(1)
+					go func(i int) {
+						defer wg.Done()
+						userID := fmt.Sprintf("bidder-%d", i)
+						_, err := repo.PlaceBid(ctx, tc.bid(lot.Id, userID, startPrice+int64(i)))
+						switch {
+						case err == nil:
+							mu.Lock()
+							accepted++
+							mu.Unlock()
+						case !errors.Is(err, storage.ErrBidTooLow):
+							t.Errorf("PlaceBid(%s): %v", userID, err)
+						}
+					}(i)
+				}
+				wg.Wait()
+
+				res, err := repo.GetLot(ctx, &models.GetLotRequest{Lot_id: lot.Id})
+				if err != nil {
+					t.Fatalf("GetLot: %v", err)
+				}
+				wantWinner := fmt.Sprintf("bidder-%d", bidders)
+				if res.Lot.CurrentWinner != wantWinner {
+					t.Errorf("winner = %q, want %q", res.Lot.CurrentWinner, wantWinner)
+				}
+				if maxPrice := int64(startPrice + bidders); res.Lot.CurrentPrice < tc.minPrice || res.Lot.CurrentPrice > maxPrice {
+					t.Errorf("current price = %d, want between %d and %d", res.Lot.CurrentPrice, tc.minPrice, maxPrice)
+				}
+				if accepted == 0 {
+					t.Fatal("no bids were accepted")
+				}
+
+				bids, err := repo.ListBids(ctx, &models.ListBidsRequest{Lot_id: lot.Id})
+				if err != nil {
+					t.Fatalf("ListBids: %v", err)
+				}
+				if bids.TotalBids < int64(accepted) {
+					t.Errorf("total bids = %d, want at least %d accepted", bids.TotalBids, accepted)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"encoding/base64"
+	"strconv"
+
+	"github.com/Lemper29/auction-service/pkg/models"
+)
+
+// PrepareListBids подставляет размер страницы по умолчанию и возвращает
+// порядковый номер ставки, после которой начинается страница.
+func PrepareListBids(req *models.ListBidsRequest) (int64, error) {
+	if req.PageSize <= 0 {
+		req.PageSize = DefaultPageSize
+	}
+	if req.PageSize > MaxPageSize {
+		req.PageSize = MaxPageSize
+	}
+
+	if req.PageToken == "" {
+		return 0, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(req.PageToken)
+	if err != nil {
+		return 0, ErrInvalidPageToken
+	}
+
+	seq, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil || seq <= 0 {
+		return 0, ErrInvalidPageToken
+	}
+
+	return seq, nil
+}
+
+// NewListBidsResponse обрезает выборку до размера страницы и строит токен следующей.
+func NewListBidsResponse(bids []models.Bid, req *models.ListBidsRequest) *models.ListBidsResponse {
+	response := &models.ListBidsResponse{Bids: bids}
+	if len(bids) > req.PageSize {
+		response.Bids = bids[:req.PageSize]
+		last := response.Bids[len(response.Bids)-1]
+		response.NextPageToken = base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(last.Seq, 10)))
+	}
+	return response
+}
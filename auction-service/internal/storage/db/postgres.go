@@ -2,15 +2,19 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/Lemper29/auction-service/internal/storage"
+	"github.com/Lemper29/auction-service/internal/storage/migrations"
 	"github.com/Lemper29/auction-service/pkg/models"
-	"github.com/google/uuid"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 )
 
@@ -18,15 +22,20 @@ type PostgresStorage struct {
 	db *gorm.DB
 }
 
-func NewPostgresDB(dsn postgres.Config) (storage.Storage, error) {
+func NewPostgresDB(dsn postgres.Config) (*PostgresStorage, error) {
 	db, err := gorm.Open(postgres.New(dsn), &gorm.Config{
-		Logger:      logger.Default.LogMode(logger.Info),
-		PrepareStmt: true,
+		Logger:         logger.Default.LogMode(logger.Info),
+		PrepareStmt:    true,
+		TranslateError: true,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	if err := db.Use(newTracingPlugin()); err != nil {
+		return nil, fmt.Errorf("failed to enable tracing: %w", err)
+	}
+
 	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, err
@@ -40,31 +49,59 @@ func NewPostgresDB(dsn postgres.Config) (storage.Storage, error) {
 	return &PostgresStorage{db: db}, nil
 }
 
-func (p *PostgresStorage) CreateLot(ctx context.Context, createLot *models.CreateLotRequest) (*models.Lot, error) {
-	id := uuid.New().String()
-	endTime := time.Now().Add(time.Duration(createLot.DurationMinute) * time.Minute)
-
-	lot := &models.Lot{
-		Id:            id,
-		Name:          createLot.Name,
-		Description:   createLot.Description,
-		StartPrice:    createLot.StartPrice,
-		CurrentPrice:  createLot.StartPrice,
-		CurrentWinner: "",
-		Status:        "ACTIVE",
-		EndTimeUnix:   endTime.Unix(),
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+// SQLDB возвращает пул соединений, например для сбора его статистики.
+func (p *PostgresStorage) SQLDB() (*sql.DB, error) {
+	return p.db.DB()
+}
+
+func (p *PostgresStorage) Ping(ctx context.Context) error {
+	sqlDB, err := p.db.DB()
+	if err != nil {
+		return err
 	}
+	return sqlDB.PingContext(ctx)
+}
+
+func (p *PostgresStorage) Close() error {
+	sqlDB, err := p.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// Migrate применяет встроенные миграции, которых ещё нет в базе.
+func (p *PostgresStorage) Migrate(ctx context.Context) error {
+	sqlDB, err := p.db.DB()
+	if err != nil {
+		return err
+	}
+	return migrations.Up(ctx, sqlDB)
+}
+
+// CheckSchema проверяет, что схема базы не отстаёт от встроенных миграций.
+func (p *PostgresStorage) CheckSchema(ctx context.Context) error {
+	sqlDB, err := p.db.DB()
+	if err != nil {
+		return err
+	}
+	return migrations.Check(ctx, sqlDB)
+}
+
+func (p *PostgresStorage) CreateLot(ctx context.Context, createLot *models.CreateLotRequest) (*models.Lot, error) {
+	lot := storage.NewLot(createLot, time.Now())
 
 	result := p.db.WithContext(ctx).Create(lot)
 	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) && lot.RelistedFromID != "" {
+			return nil, storage.ErrAlreadyRelisted
+		}
 		log.Printf("Error creating lot: %v", result.Error)
 		return nil, result.Error
 	}
 
 	var savedLot models.Lot
-	err := p.db.WithContext(ctx).First(&savedLot, "id = ?", id).Error
+	err := p.db.WithContext(ctx).First(&savedLot, "id = ?", lot.Id).Error
 	if err != nil {
 		log.Printf("Error retrieving saved lot: %v", err)
 		return nil, err
@@ -78,8 +115,8 @@ func (p *PostgresStorage) GetLot(ctx context.Context, getLot *models.GetLotReque
 	err := p.db.WithContext(ctx).First(&lot, "id = ?", getLot.Lot_id).Error
 	if err != nil {
 		log.Printf("Error getting lot: %v", err)
-		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("lot not found")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, storage.ErrLotNotFound
 		}
 		return nil, err
 	}
@@ -87,87 +124,392 @@ func (p *PostgresStorage) GetLot(ctx context.Context, getLot *models.GetLotReque
 	return &models.GetLotResponse{Lot: lot}, nil
 }
 
+var sortColumns = map[string]string{
+	storage.SortByEndTime:   "end_time_unix",
+	storage.SortByPrice:     "current_price",
+	storage.SortByCreatedAt: "created_at",
+}
+
+func (p *PostgresStorage) ListLots(ctx context.Context, listLots *models.ListLotsRequest) (*models.ListLotsResponse, error) {
+	cursor, err := storage.PrepareListLots(listLots)
+	if err != nil {
+		return nil, err
+	}
+
+	query := p.db.WithContext(ctx).Model(&models.Lot{})
+
+	if len(listLots.Statuses) > 0 {
+		query = query.Where("status IN ?", listLots.Statuses)
+	}
+	switch {
+	case listLots.ViewerAdmin:
+		// Администратор видит все черновики
+	case listLots.ViewerID == "":
+		query = query.Where("status <> ?", models.StatusDraft)
+	default:
+		query = query.Where("(status <> ? OR seller_id = ?)", models.StatusDraft, listLots.ViewerID)
+	}
+	if !listLots.MinPrice.IsZero() {
+		query = query.Where("currency = ? AND current_price >= ?", listLots.MinPrice.Currency, listLots.MinPrice.Amount)
+	}
+	if !listLots.MaxPrice.IsZero() {
+		query = query.Where("currency = ? AND current_price <= ?", listLots.MaxPrice.Currency, listLots.MaxPrice.Amount)
+	}
+	if listLots.EndingAfterUnix > 0 {
+		query = query.Where("end_time_unix > ?", listLots.EndingAfterUnix)
+	}
+	if listLots.EndingBeforeUnix > 0 {
+		query = query.Where("end_time_unix < ?", listLots.EndingBeforeUnix)
+	}
+	if listLots.Query != "" {
+		query = query.Where("name ILIKE ?", "%"+escapeLike(listLots.Query)+"%")
+	}
+
+	column := sortColumns[listLots.SortBy]
+	direction, op := "ASC", ">"
+	if listLots.Descending {
+		direction, op = "DESC", "<"
+	}
+
+	// Keyset-пагинация: продолжаем строго после последнего лота предыдущей страницы
+	if cursor != nil {
+		query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, op), cursor.Key(), cursor.ID)
+	}
+
+	var lots []models.Lot
+	err = query.
+		Order(column + " " + direction).
+		Order("id " + direction).
+		Limit(listLots.PageSize + 1).
+		Find(&lots).Error
+	if err != nil {
+		log.Printf("Error listing lots: %v", err)
+		return nil, err
+	}
+
+	return storage.NewListLotsResponse(lots, listLots), nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
 func (p *PostgresStorage) PlaceBid(ctx context.Context, placeBid *models.PlaceBidRequest) (*models.PlaceBidResponse, error) {
+	var response *models.PlaceBidResponse
+	var bidErr error
+
+	// Строка лота блокируется до конца транзакции, поэтому конкурирующие ставки
+	// выполняются последовательно и проверка цены не может устареть
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var lot models.Lot
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&lot, "id = ?", placeBid.Lot_id).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return storage.ErrLotNotFound
+			}
+			log.Printf("Error locking lot: %v", err)
+			return err
+		}
+
+		var leaderMax int64
+		if lot.CurrentWinner != "" {
+			err := tx.Model(&models.Bid{}).
+				Select("COALESCE(MAX(max_amount), 0)").
+				Where("lot_id = ? AND user_id = ?", lot.Id, lot.CurrentWinner).
+				Scan(&leaderMax).Error
+			if err != nil {
+				log.Printf("Error loading proxy bid: %v", err)
+				return err
+			}
+		}
+
+		// Отказ по правилам аукциона не откатывает транзакцию:
+		// лот, закрытый при проверке срока, всё равно сохраняется
+		bids, res, changed, err := storage.ApplyBid(&lot, placeBid, leaderMax, time.Now())
+		response, bidErr = res, err
+
+		for _, bid := range bids {
+			if err := tx.Create(bid).Error; err != nil {
+				log.Printf("Error creating bid: %v", err)
+				return err
+			}
+		}
+
+		if changed {
+			if err := tx.Save(&lot).Error; err != nil {
+				log.Printf("Error updating lot: %v", err)
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, storage.ErrLotNotFound) {
+			log.Printf("Error placing bid: %v", err)
+		}
+		return nil, err
+	}
+
+	return response, bidErr
+}
+
+func (p *PostgresStorage) ListBids(ctx context.Context, listBids *models.ListBidsRequest) (*models.ListBidsResponse, error) {
+	afterSeq, err := storage.PrepareListBids(listBids)
+	if err != nil {
+		return nil, err
+	}
+
+	var lot models.Lot
+	err = p.db.WithContext(ctx).Select("id", "currency", "seller_id").First(&lot, "id = ?", listBids.Lot_id).Error
+	if err != nil {
+		log.Printf("Error getting lot: %v", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, storage.ErrLotNotFound
+		}
+		return nil, err
+	}
+
+	var bids []models.Bid
+	err = p.db.WithContext(ctx).
+		Where("lot_id = ? AND seq > ?", listBids.Lot_id, afterSeq).
+		Order("seq").
+		Limit(listBids.PageSize + 1).
+		Find(&bids).Error
+	if err != nil {
+		log.Printf("Error listing bids: %v", err)
+		return nil, err
+	}
+
+	response := storage.NewListBidsResponse(bids, listBids)
+	response.Currency = lot.Currency
+	response.SellerID = lot.SellerID
+
+	err = p.db.WithContext(ctx).Model(&models.Bid{}).
+		Select("COUNT(*), COUNT(DISTINCT user_id)").
+		Where("lot_id = ?", listBids.Lot_id).
+		Row().
+		Scan(&response.TotalBids, &response.UniqueBidders)
+	if err != nil {
+		log.Printf("Error counting bids: %v", err)
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (p *PostgresStorage) UpdateLot(ctx context.Context, lotID string, update func(lot *models.Lot) error) (*models.Lot, error) {
 	var lot models.Lot
-	err := p.db.WithContext(ctx).First(&lot, "id = ?", placeBid.Lot_id).Error
-	if err != nil {
-		log.Printf("Lot not found: %v", err)
-		return &models.PlaceBidResponse{
-			Success: false,
-			Message: "Лот не найден",
-		}, nil
-	}
-
-	if lot.Status != "ACTIVE" {
-		return &models.PlaceBidResponse{
-			Success:     false,
-			Message:     "Аукцион для этого лота завершен",
-			Updated_lot: lot,
-		}, nil
-	}
-
-	if time.Now().Unix() > lot.EndTimeUnix {
-		lot.Status = "COMPLETED"
-		p.db.Save(&lot)
-		return &models.PlaceBidResponse{
-			Success:     false,
-			Message:     "Аукцион завершен",
-			Updated_lot: lot,
-		}, nil
-	}
-
-	if placeBid.Amount <= lot.CurrentPrice {
-		return &models.PlaceBidResponse{
-			Success:     false,
-			Message:     "Ставка должна быть выше текущей цены",
-			Updated_lot: lot,
-		}, nil
-	}
-
-	bid := &models.Bid{
-		ID:             uuid.New().String(),
-		LotId:          placeBid.Lot_id,
-		UserId:         placeBid.User_id,
-		Amount:         placeBid.Amount,
-		Timestamp_unix: time.Now().Unix(),
-		CreatedAt:      time.Now(),
-	}
-
-	if err := p.db.WithContext(ctx).Create(bid).Error; err != nil {
-		log.Printf("Error creating bid: %v", err)
-		return &models.PlaceBidResponse{
-			Success:     false,
-			Message:     "Ошибка при сохранении ставки",
-			Updated_lot: lot,
-		}, err
-	}
-
-	lot.CurrentPrice = placeBid.Amount
-	lot.CurrentWinner = placeBid.User_id
-	lot.UpdatedAt = time.Now()
-
-	if err := p.db.WithContext(ctx).Save(&lot).Error; err != nil {
-		log.Printf("Error updating lot: %v", err)
-		return &models.PlaceBidResponse{
-			Success:     false,
-			Message:     "Ошибка при обновлении лота",
-			Updated_lot: lot,
-		}, err
-	}
-
-	var updatedLot models.Lot
-	if err := p.db.WithContext(ctx).First(&updatedLot, "id = ?", placeBid.Lot_id).Error; err != nil {
-		log.Printf("Error retrieving updated lot: %v", err)
-		return &models.PlaceBidResponse{
-			Success:     false,
-			Message:     "Ошибка при получении обновленного лота",
-			Updated_lot: lot,
-		}, err
-	}
-
-	return &models.PlaceBidResponse{
-		Success:     true,
-		Message:     "Ставка принята",
-		Updated_lot: updatedLot,
-	}, nil
+
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&lot, "id = ?", lotID).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return storage.ErrLotNotFound
+			}
+			log.Printf("Error locking lot: %v", err)
+			return err
+		}
+
+		if err := update(&lot); err != nil {
+			return err
+		}
+
+		if err := tx.Save(&lot).Error; err != nil {
+			log.Printf("Error updating lot: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &lot, nil
+}
+
+func (p *PostgresStorage) ListBidders(ctx context.Context, lotID string) ([]string, error) {
+	var bidders []string
+	err := p.db.WithContext(ctx).Model(&models.Bid{}).
+		Distinct("user_id").
+		Where("lot_id = ?", lotID).
+		Pluck("user_id", &bidders).Error
+	if err != nil {
+		log.Printf("Error listing bidders: %v", err)
+		return nil, err
+	}
+
+	return bidders, nil
+}
+
+func (p *PostgresStorage) CloseExpiredLots(ctx context.Context, now time.Time, limit int) ([]models.Lot, error) {
+	var closed []models.Lot
+
+	// SKIP LOCKED позволяет нескольким репликам закрывать лоты параллельно:
+	// строку, захваченную одной репликой, другие просто пропускают
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var lots []models.Lot
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND end_time_unix < ?)",
+				models.StatusClosing, models.StatusActive, now.Unix()).
+			Order("end_time_unix").
+			Limit(limit).
+			Find(&lots).Error
+		if err != nil {
+			log.Printf("Error selecting expired lots: %v", err)
+			return err
+		}
+
+		for i := range lots {
+			if err := storage.CloseLot(&lots[i], now); err != nil {
+				log.Printf("Error closing lot %s: %v", lots[i].Id, err)
+				return err
+			}
+			if err := tx.Save(&lots[i]).Error; err != nil {
+				log.Printf("Error closing lot %s: %v", lots[i].Id, err)
+				return err
+			}
+		}
+
+		closed = lots
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return closed, nil
+}
+
+func (p *PostgresStorage) ActivateScheduledLots(ctx context.Context, now time.Time, limit int) ([]models.Lot, error) {
+	var activated []models.Lot
+
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var lots []models.Lot
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND start_time_unix <= ?", models.StatusScheduled, now.Unix()).
+			Order("start_time_unix").
+			Limit(limit).
+			Find(&lots).Error
+		if err != nil {
+			log.Printf("Error selecting scheduled lots: %v", err)
+			return err
+		}
+
+		for i := range lots {
+			if err := storage.ActivateLot(&lots[i], now); err != nil {
+				log.Printf("Error activating lot %s: %v", lots[i].Id, err)
+				return err
+			}
+			if err := tx.Save(&lots[i]).Error; err != nil {
+				log.Printf("Error activating lot %s: %v", lots[i].Id, err)
+				return err
+			}
+		}
+
+		activated = lots
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return activated, nil
+}
+
+func (p *PostgresStorage) CreateUser(ctx context.Context, createUser *models.CreateUserRequest) (*models.User, error) {
+	user := storage.NewUser(createUser, time.Now())
+
+	err := p.db.WithContext(ctx).Create(user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, storage.ErrUsernameTaken
+		}
+		log.Printf("Error creating user: %v", err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (p *PostgresStorage) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	var user models.User
+	err := p.db.WithContext(ctx).First(&user, "username = ?", username).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, storage.ErrUserNotFound
+		}
+		log.Printf("Error getting user: %v", err)
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (p *PostgresStorage) ReserveIdempotencyKey(ctx context.Context, record *models.IdempotencyRecord) (*models.IdempotencyRecord, error) {
+	var existing *models.IdempotencyRecord
+
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var stored models.IdempotencyRecord
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&stored, "key = ?", record.Key).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return tx.Create(record).Error
+		case err != nil:
+			return err
+		case storage.IdempotencyKeyReusable(&stored, record.CreatedAt):
+			return tx.Save(record).Error
+		}
+
+		existing = &stored
+		return nil
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		// Ключ одновременно занял параллельный запрос — возвращаем его запись
+		var stored models.IdempotencyRecord
+		err = p.db.WithContext(ctx).First(&stored, "key = ?", record.Key).Error
+		existing = &stored
+	}
+	if err != nil {
+		log.Printf("Error reserving idempotency key: %v", err)
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+func (p *PostgresStorage) CompleteIdempotencyKey(ctx context.Context, key string, response []byte) error {
+	err := p.db.WithContext(ctx).Model(&models.IdempotencyRecord{}).
+		Where("key = ?", key).
+		Updates(map[string]any{"response": response, "completed": true}).Error
+	if err != nil {
+		log.Printf("Error completing idempotency key: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (p *PostgresStorage) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	err := p.db.WithContext(ctx).
+		Where("key = ? AND completed = ?", key, false).
+		Delete(&models.IdempotencyRecord{}).Error
+	if err != nil {
+		log.Printf("Error releasing idempotency key: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (p *PostgresStorage) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	result := p.db.WithContext(ctx).
+		Where("expires_at <= ?", now).
+		Delete(&models.IdempotencyRecord{})
+	if result.Error != nil {
+		log.Printf("Error deleting expired idempotency keys: %v", result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
 }
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// tracingPlugin открывает спан на каждый запрос GORM. В спан пишется SQL
+// с плейсхолдерами, но не значения параметров: иначе в трассировки попадали
+// бы хеши паролей и прочие данные пользователей.
+type tracingPlugin struct {
+	tracer trace.Tracer
+}
+
+func newTracingPlugin() *tracingPlugin {
+	return &tracingPlugin{tracer: otel.Tracer("github.com/Lemper29/auction-service/internal/storage/db")}
+}
+
+func (p *tracingPlugin) Name() string {
+	return "otel-tracing"
+}
+
+func (p *tracingPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("*").Register("otel:before_create", p.before("INSERT")),
+		cb.Create().After("*").Register("otel:after_create", p.after),
+		cb.Query().Before("*").Register("otel:before_query", p.before("SELECT")),
+		cb.Query().After("*").Register("otel:after_query", p.after),
+		cb.Update().Before("*").Register("otel:before_update", p.before("UPDATE")),
+		cb.Update().After("*").Register("otel:after_update", p.after),
+		cb.Delete().Before("*").Register("otel:before_delete", p.before("DELETE")),
+		cb.Delete().After("*").Register("otel:after_delete", p.after),
+		cb.Row().Before("*").Register("otel:before_row", p.before("ROW")),
+		cb.Row().After("*").Register("otel:after_row", p.after),
+		cb.Raw().Before("*").Register("otel:before_raw", p.before("RAW")),
+		cb.Raw().After("*").Register("otel:after_raw", p.after),
+	)
+}
+
+func (p *tracingPlugin) before(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx, _ := p.tracer.Start(db.Statement.Context, "db "+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", "postgresql"),
+				attribute.String("db.operation.name", operation),
+			),
+		)
+		db.Statement.Context = ctx
+	}
+}
+
+func (p *tracingPlugin) after(db *gorm.DB) {
+	span := trace.SpanFromContext(db.Statement.Context)
+	if !span.IsRecording() {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.collection.name", db.Statement.Table),
+		attribute.String("db.query.text", db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+	// Отсутствие записи — обычный ответ, а не сбой базы
+	if err := db.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,18 @@
+package storage
+
+import "errors"
+
+// Доменные ошибки хранилищ. Сервис переводит их в gRPC-статусы,
+// поэтому хранилища возвращают их как есть или обёрнутыми через %w.
+var (
+	ErrLotNotFound      = errors.New("lot not found")
+	ErrLotNotActive     = errors.New("lot is not active")
+	ErrAuctionEnded     = errors.New("auction has ended")
+	ErrCurrencyMismatch = errors.New("bid currency does not match lot currency")
+	ErrAmountAboveMax   = errors.New("amount exceeds max_amount")
+	ErrBidTooLow        = errors.New("bid is below the minimum")
+	ErrSelfBidding      = errors.New("seller cannot bid on own lot")
+	ErrAlreadyRelisted  = errors.New("lot has already been relisted")
+	ErrUserNotFound     = errors.New("user not found")
+	ErrUsernameTaken    = errors.New("username is already taken")
+)
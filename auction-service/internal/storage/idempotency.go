@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/Lemper29/auction-service/pkg/models"
+)
+
+// IdempotencyLockTimeout — сколько ключ остаётся занятым незавершённым
+// запросом. Если процесс упал, не сохранив ответ, по истечении этого
+// времени ключ можно занять снова.
+const IdempotencyLockTimeout = time.Minute
+
+// IdempotencyKeyReusable сообщает, что запись больше не действует
+// и ключ можно занять новым запросом.
+func IdempotencyKeyReusable(record *models.IdempotencyRecord, now time.Time) bool {
+	if !now.Before(record.ExpiresAt) {
+		return true
+	}
+	return !record.Completed && now.Sub(record.CreatedAt) > IdempotencyLockTimeout
+}
@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Lemper29/auction-service/pkg/models"
+)
+
+const (
+	SortByEndTime   = "end_time"
+	SortByPrice     = "price"
+	SortByCreatedAt = "created_at"
+
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var (
+	ErrInvalidSort      = errors.New("invalid sort field")
+	ErrInvalidPageToken = errors.New("invalid page token")
+)
+
+// LotCursor — позиция последнего лота страницы в порядке сортировки.
+// Клиенту передаётся в виде непрозрачной строки.
+type LotCursor struct {
+	SortBy     string `json:"s"`
+	Descending bool   `json:"d"`
+	EndTime    int64  `json:"e,omitempty"`
+	Price      int64  `json:"p,omitempty"`
+	CreatedAt  int64  `json:"c,omitempty"`
+	ID         string `json:"i"`
+}
+
+func NewLotCursor(lot models.Lot, sortBy string, descending bool) LotCursor {
+	cursor := LotCursor{SortBy: sortBy, Descending: descending, ID: lot.Id}
+	switch sortBy {
+	case SortByEndTime:
+		cursor.EndTime = lot.EndTimeUnix
+	case SortByPrice:
+		cursor.Price = lot.CurrentPrice
+	case SortByCreatedAt:
+		cursor.CreatedAt = lot.CreatedAt.UnixNano()
+	}
+	return cursor
+}
+
+// Key возвращает значение ключа сортировки, сохранённое в курсоре.
+func (c LotCursor) Key() any {
+	switch c.SortBy {
+	case SortByPrice:
+		return c.Price
+	case SortByCreatedAt:
+		return time.Unix(0, c.CreatedAt).UTC()
+	default:
+		return c.EndTime
+	}
+}
+
+func (c LotCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// PrepareListLots проверяет запрос, подставляет значения по умолчанию
+// и декодирует курсор (nil для первой страницы).
+func PrepareListLots(req *models.ListLotsRequest) (*LotCursor, error) {
+	if req.SortBy == "" {
+		req.SortBy = SortByEndTime
+	}
+	switch req.SortBy {
+	case SortByEndTime, SortByPrice, SortByCreatedAt:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSort, req.SortBy)
+	}
+
+	if req.PageSize <= 0 {
+		req.PageSize = DefaultPageSize
+	}
+	if req.PageSize > MaxPageSize {
+		req.PageSize = MaxPageSize
+	}
+
+	if req.PageToken == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(req.PageToken)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	var cursor LotCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	if cursor.SortBy != req.SortBy || cursor.Descending != req.Descending || cursor.ID == "" {
+		return nil, ErrInvalidPageToken
+	}
+
+	return &cursor, nil
+}
+
+// NewListLotsResponse обрезает выборку до размера страницы. Хранилище
+// запрашивает на один лот больше, чтобы понять, есть ли следующая страница.
+func NewListLotsResponse(lots []models.Lot, req *models.ListLotsRequest) *models.ListLotsResponse {
+	response := &models.ListLotsResponse{Lots: lots}
+	if len(lots) > req.PageSize {
+		response.Lots = lots[:req.PageSize]
+		last := response.Lots[len(response.Lots)-1]
+		response.NextPageToken = NewLotCursor(last, req.SortBy, req.Descending).Encode()
+	}
+	return response
+}
+
+// MatchLot проверяет лот на соответствие фильтрам запроса.
+func MatchLot(lot models.Lot, req *models.ListLotsRequest) bool {
+	if len(req.Statuses) > 0 && !slices.Contains(req.Statuses, lot.Status) {
+		return false
+	}
+	if lot.Status == models.StatusDraft && !req.ViewerAdmin && (req.ViewerID == "" || lot.SellerID != req.ViewerID) {
+		return false
+	}
+	if !req.MinPrice.IsZero() && (lot.Currency != req.MinPrice.Currency || lot.CurrentPrice < req.MinPrice.Amount) {
+		return false
+	}
+	if !req.MaxPrice.IsZero() && (lot.Currency != req.MaxPrice.Currency || lot.CurrentPrice > req.MaxPrice.Amount) {
+		return false
+	}
+	if req.EndingAfterUnix > 0 && lot.EndTimeUnix <= req.EndingAfterUnix {
+		return false
+	}
+	if req.EndingBeforeUnix > 0 && lot.EndTimeUnix >= req.EndingBeforeUnix {
+		return false
+	}
+	if req.Query != "" && !strings.Contains(strings.ToLower(lot.Name), strings.ToLower(req.Query)) {
+		return false
+	}
+	return true
+}
+
+// CompareLots сравнивает лоты по ключу сортировки, а при равенстве — по id.
+func CompareLots(a, b LotCursor) int {
+	var result int
+	switch a.SortBy {
+	case SortByPrice:
+		result = cmp.Compare(a.Price, b.Price)
+	case SortByCreatedAt:
+		result = cmp.Compare(a.CreatedAt, b.CreatedAt)
+	default:
+		result = cmp.Compare(a.EndTime, b.EndTime)
+	}
+	if result == 0 {
+		result = strings.Compare(a.ID, b.ID)
+	}
+	if a.Descending {
+		return -result
+	}
+	return result
+}
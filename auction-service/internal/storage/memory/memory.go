@@ -0,0 +1,319 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Lemper29/auction-service/internal/storage"
+	"github.com/Lemper29/auction-service/pkg/models"
+)
+
+// MemoryStorage хранит лоты, ставки и пользователей в памяти процесса.
+// Предназначено для тестов и локальной разработки без PostgreSQL.
+type MemoryStorage struct {
+	mu     sync.RWMutex
+	lots   map[string]models.Lot
+	bids   map[string][]models.Bid
+	bidSeq int64
+
+	usersByName map[string]models.User
+	idempotency map[string]models.IdempotencyRecord
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		lots: make(map[string]models.Lot),
+		bids: make(map[string][]models.Bid),
+
+		usersByName: make(map[string]models.User),
+		idempotency: make(map[string]models.IdempotencyRecord),
+	}
+}
+
+func (m *MemoryStorage) CreateLot(ctx context.Context, createLot *models.CreateLotRequest) (*models.Lot, error) {
+	lot := storage.NewLot(createLot, time.Now())
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if lot.RelistedFromID != "" {
+		for _, existing := range m.lots {
+			if existing.RelistedFromID == lot.RelistedFromID {
+				return nil, storage.ErrAlreadyRelisted
+			}
+		}
+	}
+
+	m.lots[lot.Id] = *lot
+
+	savedLot := *lot
+	return &savedLot, nil
+}
+
+func (m *MemoryStorage) GetLot(ctx context.Context, getLot *models.GetLotRequest) (*models.GetLotResponse, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	lot, ok := m.lots[getLot.Lot_id]
+	if !ok {
+		return nil, storage.ErrLotNotFound
+	}
+
+	return &models.GetLotResponse{Lot: lot}, nil
+}
+
+func (m *MemoryStorage) ListLots(ctx context.Context, listLots *models.ListLotsRequest) (*models.ListLotsResponse, error) {
+	cursor, err := storage.PrepareListLots(listLots)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	var lots []models.Lot
+	for _, lot := range m.lots {
+		if !storage.MatchLot(lot, listLots) {
+			continue
+		}
+		if cursor != nil && storage.CompareLots(storage.NewLotCursor(lot, cursor.SortBy, cursor.Descending), *cursor) <= 0 {
+			continue
+		}
+		lots = append(lots, lot)
+	}
+	m.mu.RUnlock()
+
+	slices.SortFunc(lots, func(a, b models.Lot) int {
+		return storage.CompareLots(
+			storage.NewLotCursor(a, listLots.SortBy, listLots.Descending),
+			storage.NewLotCursor(b, listLots.SortBy, listLots.Descending),
+		)
+	})
+
+	return storage.NewListLotsResponse(lots, listLots), nil
+}
+
+func (m *MemoryStorage) PlaceBid(ctx context.Context, placeBid *models.PlaceBidRequest) (*models.PlaceBidResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lot, ok := m.lots[placeBid.Lot_id]
+	if !ok {
+		return nil, storage.ErrLotNotFound
+	}
+
+	var leaderMax int64
+	for _, bid := range m.bids[lot.Id] {
+		if bid.UserId == lot.CurrentWinner && bid.MaxAmount > leaderMax {
+			leaderMax = bid.MaxAmount
+		}
+	}
+
+	bids, response, changed, err := storage.ApplyBid(&lot, placeBid, leaderMax, time.Now())
+
+	for _, bid := range bids {
+		m.bidSeq++
+		bid.Seq = m.bidSeq
+		m.bids[lot.Id] = append(m.bids[lot.Id], *bid)
+	}
+
+	if changed {
+		m.lots[lot.Id] = lot
+	}
+
+	return response, err
+}
+
+func (m *MemoryStorage) ListBids(ctx context.Context, listBids *models.ListBidsRequest) (*models.ListBidsResponse, error) {
+	afterSeq, err := storage.PrepareListBids(listBids)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	lot, ok := m.lots[listBids.Lot_id]
+	if !ok {
+		return nil, storage.ErrLotNotFound
+	}
+
+	all := m.bids[listBids.Lot_id]
+	bidders := make(map[string]struct{})
+	var bids []models.Bid
+	for _, bid := range all {
+		bidders[bid.UserId] = struct{}{}
+		if bid.Seq > afterSeq && len(bids) <= listBids.PageSize {
+			bids = append(bids, bid)
+		}
+	}
+
+	response := storage.NewListBidsResponse(bids, listBids)
+	response.Currency = lot.Currency
+	response.SellerID = lot.SellerID
+	response.TotalBids = int64(len(all))
+	response.UniqueBidders = int64(len(bidders))
+
+	return response, nil
+}
+
+func (m *MemoryStorage) UpdateLot(ctx context.Context, lotID string, update func(lot *models.Lot) error) (*models.Lot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lot, ok := m.lots[lotID]
+	if !ok {
+		return nil, storage.ErrLotNotFound
+	}
+
+	if err := update(&lot); err != nil {
+		return nil, err
+	}
+	m.lots[lotID] = lot
+
+	return &lot, nil
+}
+
+func (m *MemoryStorage) ListBidders(ctx context.Context, lotID string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var bidders []string
+	for _, bid := range m.bids[lotID] {
+		if !slices.Contains(bidders, bid.UserId) {
+			bidders = append(bidders, bid.UserId)
+		}
+	}
+
+	return bidders, nil
+}
+
+func (m *MemoryStorage) CloseExpiredLots(ctx context.Context, now time.Time, limit int) ([]models.Lot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var closed []models.Lot
+	for id, lot := range m.lots {
+		if len(closed) >= limit {
+			break
+		}
+		expired := lot.Status == models.StatusActive && lot.EndTimeUnix < now.Unix()
+		if lot.Status != models.StatusClosing && !expired {
+			continue
+		}
+
+		if err := storage.CloseLot(&lot, now); err != nil {
+			return closed, err
+		}
+		m.lots[id] = lot
+		closed = append(closed, lot)
+	}
+
+	return closed, nil
+}
+
+func (m *MemoryStorage) ActivateScheduledLots(ctx context.Context, now time.Time, limit int) ([]models.Lot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var activated []models.Lot
+	for id, lot := range m.lots {
+		if len(activated) >= limit {
+			break
+		}
+		if lot.Status != models.StatusScheduled || lot.StartTimeUnix > now.Unix() {
+			continue
+		}
+
+		if err := storage.ActivateLot(&lot, now); err != nil {
+			return activated, err
+		}
+		m.lots[id] = lot
+		activated = append(activated, lot)
+	}
+
+	return activated, nil
+}
+
+func (m *MemoryStorage) CreateUser(ctx context.Context, createUser *models.CreateUserRequest) (*models.User, error) {
+	user := storage.NewUser(createUser, time.Now())
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.usersByName[user.Username]; ok {
+		return nil, storage.ErrUsernameTaken
+	}
+	m.usersByName[user.Username] = *user
+
+	savedUser := *user
+	return &savedUser, nil
+}
+
+func (m *MemoryStorage) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	user, ok := m.usersByName[username]
+	if !ok {
+		return nil, storage.ErrUserNotFound
+	}
+
+	return &user, nil
+}
+
+func (m *MemoryStorage) ReserveIdempotencyKey(ctx context.Context, record *models.IdempotencyRecord) (*models.IdempotencyRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if stored, ok := m.idempotency[record.Key]; ok && !storage.IdempotencyKeyReusable(&stored, record.CreatedAt) {
+		return &stored, nil
+	}
+	m.idempotency[record.Key] = *record
+
+	return nil, nil
+}
+
+func (m *MemoryStorage) CompleteIdempotencyKey(ctx context.Context, key string, response []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if record, ok := m.idempotency[key]; ok {
+		record.Response = response
+		record.Completed = true
+		m.idempotency[key] = record
+	}
+	return nil
+}
+
+func (m *MemoryStorage) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if record, ok := m.idempotency[key]; ok && !record.Completed {
+		delete(m.idempotency, key)
+	}
+	return nil
+}
+
+func (m *MemoryStorage) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var deleted int64
+	for key, record := range m.idempotency {
+		if !now.Before(record.ExpiresAt) {
+			delete(m.idempotency, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (m *MemoryStorage) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (m *MemoryStorage) Close() error {
+	return nil
+}
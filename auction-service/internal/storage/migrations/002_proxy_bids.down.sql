@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_bids_lot_user;
+ALTER TABLE bids DROP COLUMN IF EXISTS max_amount;
@@ -0,0 +1,4 @@
+-- Скрытый максимум автоматической ставки
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS max_amount DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_bids_lot_user ON bids(lot_id, user_id);
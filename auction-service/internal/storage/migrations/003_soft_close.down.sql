@@ -0,0 +1,2 @@
+ALTER TABLE lots DROP COLUMN IF EXISTS soft_close_extension_minutes;
+ALTER TABLE lots DROP COLUMN IF EXISTS soft_close_window_minutes;
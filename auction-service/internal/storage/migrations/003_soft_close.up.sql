@@ -0,0 +1,3 @@
+-- Настройки анти-снайпинга
+ALTER TABLE lots ADD COLUMN IF NOT EXISTS soft_close_window_minutes BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE lots ADD COLUMN IF NOT EXISTS soft_close_extension_minutes BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE lots DROP COLUMN IF EXISTS buy_now_price;
+ALTER TABLE lots DROP COLUMN IF EXISTS reserve_price;
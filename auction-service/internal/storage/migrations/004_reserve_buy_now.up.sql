@@ -0,0 +1,3 @@
+-- Резервная цена и цена мгновенной покупки
+ALTER TABLE lots ADD COLUMN IF NOT EXISTS reserve_price DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE lots ADD COLUMN IF NOT EXISTS buy_now_price DOUBLE PRECISION NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_bids_lot_seq;
+ALTER TABLE bids DROP COLUMN IF EXISTS seq;
@@ -0,0 +1,5 @@
+-- Порядковый номер ставки: автоматические ставки создаются в одной
+-- транзакции с одинаковым временем, поэтому хронология строится по seq
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS seq BIGSERIAL;
+
+CREATE INDEX IF NOT EXISTS idx_bids_lot_seq ON bids(lot_id, seq);
@@ -0,0 +1,21 @@
+ALTER TABLE bids DROP CONSTRAINT IF EXISTS chk_bids_amounts_non_negative;
+ALTER TABLE lots DROP CONSTRAINT IF EXISTS chk_lots_prices_non_negative;
+
+ALTER TABLE bids ALTER COLUMN max_amount DROP DEFAULT;
+ALTER TABLE bids ALTER COLUMN max_amount TYPE DOUBLE PRECISION USING max_amount / 100.0;
+ALTER TABLE bids ALTER COLUMN max_amount SET DEFAULT 0;
+
+ALTER TABLE bids ALTER COLUMN amount TYPE DOUBLE PRECISION USING amount / 100.0;
+
+ALTER TABLE lots ALTER COLUMN buy_now_price DROP DEFAULT;
+ALTER TABLE lots ALTER COLUMN buy_now_price TYPE DOUBLE PRECISION USING buy_now_price / 100.0;
+ALTER TABLE lots ALTER COLUMN buy_now_price SET DEFAULT 0;
+
+ALTER TABLE lots ALTER COLUMN reserve_price DROP DEFAULT;
+ALTER TABLE lots ALTER COLUMN reserve_price TYPE DOUBLE PRECISION USING reserve_price / 100.0;
+ALTER TABLE lots ALTER COLUMN reserve_price SET DEFAULT 0;
+
+ALTER TABLE lots ALTER COLUMN current_price TYPE DOUBLE PRECISION USING current_price / 100.0;
+ALTER TABLE lots ALTER COLUMN start_price TYPE DOUBLE PRECISION USING start_price / 100.0;
+
+ALTER TABLE lots DROP COLUMN IF EXISTS currency;
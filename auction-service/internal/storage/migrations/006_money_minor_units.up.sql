@@ -0,0 +1,25 @@
+-- Денежные суммы переводятся из DOUBLE PRECISION в целые минимальные единицы.
+-- Существующие лоты считаются рублёвыми (2 знака после запятой).
+ALTER TABLE lots ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'RUB';
+
+ALTER TABLE lots ALTER COLUMN start_price TYPE BIGINT USING ROUND(start_price * 100)::BIGINT;
+ALTER TABLE lots ALTER COLUMN current_price TYPE BIGINT USING ROUND(current_price * 100)::BIGINT;
+
+ALTER TABLE lots ALTER COLUMN reserve_price DROP DEFAULT;
+ALTER TABLE lots ALTER COLUMN reserve_price TYPE BIGINT USING ROUND(reserve_price * 100)::BIGINT;
+ALTER TABLE lots ALTER COLUMN reserve_price SET DEFAULT 0;
+
+ALTER TABLE lots ALTER COLUMN buy_now_price DROP DEFAULT;
+ALTER TABLE lots ALTER COLUMN buy_now_price TYPE BIGINT USING ROUND(buy_now_price * 100)::BIGINT;
+ALTER TABLE lots ALTER COLUMN buy_now_price SET DEFAULT 0;
+
+ALTER TABLE bids ALTER COLUMN amount TYPE BIGINT USING ROUND(amount * 100)::BIGINT;
+
+ALTER TABLE bids ALTER COLUMN max_amount DROP DEFAULT;
+ALTER TABLE bids ALTER COLUMN max_amount TYPE BIGINT USING ROUND(max_amount * 100)::BIGINT;
+ALTER TABLE bids ALTER COLUMN max_amount SET DEFAULT 0;
+
+ALTER TABLE lots ADD CONSTRAINT chk_lots_prices_non_negative
+    CHECK (start_price >= 0 AND current_price >= 0 AND reserve_price >= 0 AND buy_now_price >= 0);
+ALTER TABLE bids ADD CONSTRAINT chk_bids_amounts_non_negative
+    CHECK (amount >= 0 AND max_amount >= 0);
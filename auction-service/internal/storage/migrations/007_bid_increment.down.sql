@@ -0,0 +1 @@
+ALTER TABLE lots DROP COLUMN IF EXISTS bid_increment;
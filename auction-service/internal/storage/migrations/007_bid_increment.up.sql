@@ -0,0 +1,2 @@
+-- Правило шага ставки лота; NULL у старых лотов означает шаг в одну минимальную единицу
+ALTER TABLE lots ADD COLUMN IF NOT EXISTS bid_increment JSONB;
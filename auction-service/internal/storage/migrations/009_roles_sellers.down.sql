@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_lots_seller_id;
+
+ALTER TABLE lots DROP COLUMN IF EXISTS seller_id;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
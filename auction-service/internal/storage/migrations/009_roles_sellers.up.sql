@@ -0,0 +1,5 @@
+-- Роли пользователей и владелец лота
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'bidder';
+ALTER TABLE lots ADD COLUMN IF NOT EXISTS seller_id VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_lots_seller_id ON lots(seller_id);
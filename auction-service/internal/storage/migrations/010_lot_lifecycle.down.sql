@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_lots_relisted_from_id;
+
+ALTER TABLE lots DROP COLUMN IF EXISTS relisted_from_id;
+ALTER TABLE lots DROP COLUMN IF EXISTS cancel_reason;
@@ -0,0 +1,6 @@
+-- Отмена и повторное выставление лотов
+ALTER TABLE lots ADD COLUMN IF NOT EXISTS cancel_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE lots ADD COLUMN IF NOT EXISTS relisted_from_id VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Лот можно выставить повторно только один раз
+CREATE UNIQUE INDEX IF NOT EXISTS idx_lots_relisted_from_id ON lots(relisted_from_id) WHERE relisted_from_id <> '';
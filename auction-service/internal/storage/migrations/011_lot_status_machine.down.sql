@@ -0,0 +1,19 @@
+-- Код до машины состояний знает только ACTIVE, COMPLETED, SOLD, UNSOLD и CANCELLED.
+-- Черновики и запланированные лоты он опубликовал бы сразу, поэтому откат
+-- отказывается, пока такие лоты есть: их нужно опубликовать или отменить.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM lots WHERE status IN ('DRAFT', 'SCHEDULED')) THEN
+        RAISE EXCEPTION 'cannot roll back lot status machine: lots in DRAFT or SCHEDULED status exist';
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS idx_lots_scheduled_start;
+
+ALTER TABLE lots DROP CONSTRAINT IF EXISTS lots_status_check;
+
+-- Истёкший ACTIVE-лот прежний планировщик закроет сам
+UPDATE lots SET status = 'ACTIVE' WHERE status = 'CLOSING';
+UPDATE lots SET status = 'COMPLETED' WHERE status = 'SOLD';
+
+ALTER TABLE lots DROP COLUMN IF EXISTS start_time_unix;
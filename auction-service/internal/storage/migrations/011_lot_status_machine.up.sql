@@ -0,0 +1,15 @@
+-- Запланированное начало торгов и единый набор статусов лота
+ALTER TABLE lots ADD COLUMN IF NOT EXISTS start_time_unix BIGINT NOT NULL DEFAULT 0;
+UPDATE lots SET start_time_unix = EXTRACT(EPOCH FROM created_at)::BIGINT WHERE start_time_unix = 0;
+
+-- Прежний планировщик записывал COMPLETED при любом итоге торгов,
+-- поэтому лот без победителя не продан
+UPDATE lots
+SET status = CASE WHEN COALESCE(current_winner, '') <> '' THEN 'SOLD' ELSE 'UNSOLD' END
+WHERE status = 'COMPLETED';
+
+ALTER TABLE lots ADD CONSTRAINT lots_status_check
+    CHECK (status IN ('DRAFT', 'SCHEDULED', 'ACTIVE', 'CLOSING', 'SOLD', 'UNSOLD', 'CANCELLED'));
+
+-- Для LotActivator
+CREATE INDEX IF NOT EXISTS idx_lots_scheduled_start ON lots(start_time_unix) WHERE status = 'SCHEDULED';
@@ -0,0 +1,191 @@
+// Package migrations встраивает SQL-миграции в бинарный файл сервиса и
+// применяет их к PostgreSQL. Таблица schema_migrations совместима с CLI
+// golang-migrate, поэтому откатывать миграции можно и через make migrate-down.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// advisoryLockID — ключ pg_advisory_lock, общий для всех реплик: миграции
+// одновременно применяет только одна из них, остальные ждут.
+const advisoryLockID int64 = 0x61756374696f6e // "auction"
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.up\.sql$`)
+
+var ErrSchemaOutdated = errors.New("database schema is outdated")
+
+type Migration struct {
+	Version int64
+	Name    string
+	SQL     string
+}
+
+// Load возвращает встроенные миграции в порядке версий.
+func Load() ([]Migration, error) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []Migration
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", entry.Name(), err)
+		}
+		body, err := files.ReadFile(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, Migration{Version: version, Name: match[2], SQL: string(body)})
+	}
+
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return int(a.Version - b.Version)
+	})
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version == migrations[i-1].Version {
+			return nil, fmt.Errorf("duplicate migration version %d", migrations[i].Version)
+		}
+	}
+
+	return migrations, nil
+}
+
+// Up применяет все миграции новее текущей версии схемы. Каждая миграция
+// выполняется в своей транзакции вместе с записью новой версии.
+func Up(ctx context.Context, db *sql.DB) error {
+	migrations, err := Load()
+	if err != nil {
+		return err
+	}
+
+	// Advisory lock живёт в сессии, поэтому вся работа идёт через одно соединение
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", advisoryLockID); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", advisoryLockID)
+
+	if _, err := conn.ExecContext(ctx,
+		"CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)",
+	); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	current, err := version(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+
+		log.Printf("Applying migration %03d_%s", m.Version, m.Name)
+		if err := apply(ctx, conn, m); err != nil {
+			return fmt.Errorf("migration %03d_%s: %w", m.Version, m.Name, err)
+		}
+		current = m.Version
+	}
+
+	log.Printf("Database schema is at version %d", current)
+	return nil
+}
+
+// Check проверяет, что к базе применены все встроенные миграции.
+func Check(ctx context.Context, db *sql.DB) error {
+	migrations, err := Load()
+	if err != nil {
+		return err
+	}
+	if len(migrations) == 0 {
+		return nil
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	current, err := version(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	latest := migrations[len(migrations)-1].Version
+	if current < latest {
+		return fmt.Errorf("%w: version %d, expected %d", ErrSchemaOutdated, current, latest)
+	}
+	return nil
+}
+
+// version возвращает текущую версию схемы; 0, если миграции не применялись.
+// Схема, на которой миграция оборвалась (dirty), требует ручного исправления.
+func version(ctx context.Context, conn *sql.Conn) (int64, error) {
+	var exists bool
+	err := conn.QueryRowContext(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists)
+	if err != nil || !exists {
+		return 0, err
+	}
+
+	var current int64
+	var dirty bool
+	err = conn.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&current, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("read schema version: %w", err)
+	}
+	if dirty {
+		return 0, fmt.Errorf("database schema is dirty at version %d, fix it manually", current)
+	}
+
+	return current, nil
+}
+
+func apply(ctx context.Context, conn *sql.Conn, m Migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.SQL); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations"); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)", m.Version); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
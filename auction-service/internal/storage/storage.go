@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"github.com/Lemper29/auction-service/pkg/models"
 )
@@ -9,5 +10,40 @@ import (
 type Storage interface {
 	CreateLot(ctx context.Context, req *models.CreateLotRequest) (*models.Lot, error)
 	GetLot(ctx context.Context, req *models.GetLotRequest) (*models.GetLotResponse, error)
+	ListLots(ctx context.Context, req *models.ListLotsRequest) (*models.ListLotsResponse, error)
+	// PlaceBid при отказе по правилам аукциона возвращает доменную ошибку
+	// и, если лот найден, его актуальное состояние
 	PlaceBid(ctx context.Context, req *models.PlaceBidRequest) (*models.PlaceBidResponse, error)
+	ListBids(ctx context.Context, req *models.ListBidsRequest) (*models.ListBidsResponse, error)
+	// UpdateLot блокирует лот и вызывает update с его текущим состоянием.
+	// Если update вернул nil, изменённый лот сохраняется и возвращается;
+	// ошибка update отменяет изменение и возвращается как есть.
+	UpdateLot(ctx context.Context, lotID string, update func(lot *models.Lot) error) (*models.Lot, error)
+	// ListBidders возвращает всех, кто делал ставки на лот
+	ListBidders(ctx context.Context, lotID string) ([]string, error)
+	// CloseExpiredLots подводит итог не более чем limit лотам: активным, срок
+	// которых истёк к моменту now, и уже переведённым в CLOSING. Возвращает
+	// их итоговое состояние
+	CloseExpiredLots(ctx context.Context, now time.Time, limit int) ([]models.Lot, error)
+	// ActivateScheduledLots открывает торги не более чем по limit лотам в статусе
+	// SCHEDULED, время начала которых наступило к моменту now
+	ActivateScheduledLots(ctx context.Context, now time.Time, limit int) ([]models.Lot, error)
+
+	CreateUser(ctx context.Context, req *models.CreateUserRequest) (*models.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
+
+	// ReserveIdempotencyKey занимает ключ за новым запросом и возвращает nil.
+	// Если ключ уже занят действующей записью, она возвращается без изменений
+	ReserveIdempotencyKey(ctx context.Context, record *models.IdempotencyRecord) (*models.IdempotencyRecord, error)
+	// CompleteIdempotencyKey сохраняет ответ на запрос, занявший ключ
+	CompleteIdempotencyKey(ctx context.Context, key string, response []byte) error
+	// ReleaseIdempotencyKey освобождает ключ незавершённого запроса
+	ReleaseIdempotencyKey(ctx context.Context, key string) error
+	// DeleteExpiredIdempotencyKeys удаляет записи, истёкшие к моменту now
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error)
+
+	// Ping проверяет, что хранилище доступно
+	Ping(ctx context.Context) error
+	// Close освобождает соединения хранилища при остановке сервиса
+	Close() error
 }
@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/Lemper29/auction-service/pkg/models"
+	"github.com/google/uuid"
+)
+
+// NewUser строит учётную запись из запроса на регистрацию.
+func NewUser(createUser *models.CreateUserRequest, now time.Time) *models.User {
+	return &models.User{
+		ID:           uuid.New().String(),
+		Username:     createUser.Username,
+		PasswordHash: createUser.PasswordHash,
+		Role:         createUser.Role,
+		CreatedAt:    now,
+	}
+}
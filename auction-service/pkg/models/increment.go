@@ -0,0 +1,128 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	IncrementFixed   = "fixed"
+	IncrementPercent = "percent"
+	IncrementTiered  = "tiered"
+)
+
+var ErrInvalidIncrement = errors.New("invalid bid increment")
+
+// IncrementRule — правило минимального шага ставки. Суммы задаются
+// в минимальных единицах валюты лота, процент — в базисных пунктах.
+type IncrementRule struct {
+	Type       string          `json:"type"`
+	Fixed      int64           `json:"fixed,omitempty"`
+	PercentBps int64           `json:"percentBps,omitempty"`
+	Tiers      []IncrementTier `json:"tiers,omitempty"`
+}
+
+// IncrementTier действует для цен от From (включительно) до начала следующего диапазона.
+type IncrementTier struct {
+	From      int64 `json:"from"`
+	Increment int64 `json:"increment"`
+}
+
+// Increment возвращает шаг ставки при текущей цене price; не меньше одной
+// минимальной единицы. Лоты без правила (созданные до его появления)
+// принимают любую ставку выше текущей цены.
+func (r *IncrementRule) Increment(price int64) int64 {
+	if r == nil {
+		return 1
+	}
+
+	var step int64
+	switch r.Type {
+	case IncrementFixed:
+		step = r.Fixed
+	case IncrementPercent:
+		// Округление вверх, чтобы шаг не обнулялся на малых ценах
+		step = (price*r.PercentBps + 9999) / 10000
+	case IncrementTiered:
+		for _, tier := range r.Tiers {
+			if price < tier.From {
+				break
+			}
+			step = tier.Increment
+		}
+	}
+
+	return max(step, 1)
+}
+
+// Validate проверяет правило, пришедшее от клиента или из конфигурации.
+func (r *IncrementRule) Validate() error {
+	switch r.Type {
+	case IncrementFixed:
+		if r.Fixed <= 0 {
+			return fmt.Errorf("%w: fixed increment must be positive", ErrInvalidIncrement)
+		}
+	case IncrementPercent:
+		if r.PercentBps <= 0 || r.PercentBps > 10000 {
+			return fmt.Errorf("%w: percent must be between 1 and 10000 basis points", ErrInvalidIncrement)
+		}
+	case IncrementTiered:
+		if len(r.Tiers) == 0 || r.Tiers[0].From != 0 {
+			return fmt.Errorf("%w: first tier must start at 0", ErrInvalidIncrement)
+		}
+		for i, tier := range r.Tiers {
+			if tier.Increment <= 0 {
+				return fmt.Errorf("%w: tier increment must be positive", ErrInvalidIncrement)
+			}
+			if i > 0 && tier.From <= r.Tiers[i-1].From {
+				return fmt.Errorf("%w: tiers must be sorted by price", ErrInvalidIncrement)
+			}
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidIncrement, r.Type)
+	}
+	return nil
+}
+
+// ParseIncrementRule разбирает правило из конфигурации:
+// "fixed:100", "percent:250" или "tiered:0=100,100000=500,1000000=2500".
+func ParseIncrementRule(s string) (*IncrementRule, error) {
+	kind, value, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidIncrement, s)
+	}
+
+	rule := &IncrementRule{Type: kind}
+	var err error
+	switch kind {
+	case IncrementFixed:
+		rule.Fixed, err = strconv.ParseInt(value, 10, 64)
+	case IncrementPercent:
+		rule.PercentBps, err = strconv.ParseInt(value, 10, 64)
+	case IncrementTiered:
+		for _, part := range strings.Split(value, ",") {
+			from, step, ok := strings.Cut(part, "=")
+			if !ok {
+				return nil, fmt.Errorf("%w: tier %q", ErrInvalidIncrement, part)
+			}
+			var tier IncrementTier
+			if tier.From, err = strconv.ParseInt(strings.TrimSpace(from), 10, 64); err != nil {
+				break
+			}
+			if tier.Increment, err = strconv.ParseInt(strings.TrimSpace(step), 10, 64); err != nil {
+				break
+			}
+			rule.Tiers = append(rule.Tiers, tier)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIncrement, err)
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
@@ -2,19 +2,51 @@ package models
 
 import (
 	"time"
+
+	"github.com/Lemper29/auction-service/pkg/money"
 )
 
+// Все цены лота хранятся в минимальных единицах его валюты
 type Lot struct {
 	Id            string    `gorm:"primaryKey;column:id" json:"id"`
 	Name          string    `gorm:"column:name" json:"name"`
 	Description   string    `gorm:"column:description" json:"description"`
-	StartPrice    float64   `gorm:"column:start_price" json:"startPrice"`
-	CurrentPrice  float64   `gorm:"column:current_price" json:"currentPrice"`
+	Currency      string    `gorm:"column:currency" json:"currency"`
+	StartPrice    int64     `gorm:"column:start_price" json:"startPrice"`
+	CurrentPrice  int64     `gorm:"column:current_price" json:"currentPrice"`
 	CurrentWinner string    `gorm:"column:current_winner" json:"currentWinner"`
-	Status        string    `gorm:"column:status" json:"status"`
+	SellerID      string    `gorm:"column:seller_id" json:"sellerId"`
+	Status        LotStatus `gorm:"column:status" json:"status"`
+	StartTimeUnix int64     `gorm:"column:start_time_unix" json:"startTimeUnix"`
 	EndTimeUnix   int64     `gorm:"column:end_time_unix" json:"endTimeUnix"`
 	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
 	UpdatedAt     time.Time `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+
+	SoftCloseWindowMinutes    int64 `gorm:"column:soft_close_window_minutes" json:"softCloseWindowMinutes"`
+	SoftCloseExtensionMinutes int64 `gorm:"column:soft_close_extension_minutes" json:"softCloseExtensionMinutes"`
+
+	ReservePrice int64 `gorm:"column:reserve_price" json:"-"`
+	BuyNowPrice  int64 `gorm:"column:buy_now_price" json:"buyNowPrice"`
+
+	BidIncrement *IncrementRule `gorm:"column:bid_increment;serializer:json" json:"bidIncrement"`
+
+	CancelReason   string `gorm:"column:cancel_reason" json:"cancelReason"`
+	RelistedFromID string `gorm:"column:relisted_from_id" json:"relistedFromId"`
+}
+
+// Money возвращает сумму в валюте лота.
+func (l Lot) Money(amount int64) money.Money {
+	return money.New(amount, l.Currency)
+}
+
+// ReserveMet сообщает, есть ли у лота победитель с ценой не ниже резервной.
+func (l Lot) ReserveMet() bool {
+	return l.CurrentWinner != "" && l.CurrentPrice >= l.ReservePrice
+}
+
+// NextMinBid — минимальная ставка, которую лот примет сейчас.
+func (l Lot) NextMinBid() int64 {
+	return l.CurrentPrice + l.BidIncrement.Increment(l.CurrentPrice)
 }
 
 func (Lot) TableName() string {
@@ -23,9 +55,11 @@ func (Lot) TableName() string {
 
 type Bid struct {
 	ID             string    `gorm:"primaryKey;column:id" json:"id"`
+	Seq            int64     `gorm:"column:seq;<-:false" json:"-"`
 	LotId          string    `gorm:"column:lot_id" json:"lotId"`
 	UserId         string    `gorm:"column:user_id" json:"userId"`
-	Amount         float64   `gorm:"column:amount" json:"amount"`
+	Amount         int64     `gorm:"column:amount" json:"amount"`
+	MaxAmount      int64     `gorm:"column:max_amount" json:"-"`
 	Timestamp_unix int64     `gorm:"column:timestamp_unix" json:"timestamp_unix"`
 	CreatedAt      time.Time `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
 }
@@ -34,11 +68,57 @@ func (Bid) TableName() string {
 	return "bids"
 }
 
+const (
+	RoleBidder = "bidder"
+	RoleSeller = "seller"
+	RoleAdmin  = "admin"
+)
+
+type User struct {
+	ID           string    `gorm:"primaryKey;column:id" json:"id"`
+	Username     string    `gorm:"column:username" json:"username"`
+	PasswordHash string    `gorm:"column:password_hash" json:"-"`
+	Role         string    `gorm:"column:role" json:"role"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+}
+
+func (User) TableName() string {
+	return "users"
+}
+
+// IdempotencyRecord связывает ключ идемпотентности с ответом на первый
+// запрос. Пока запрос выполняется, Completed == false и ответа нет.
+type IdempotencyRecord struct {
+	Key         string    `gorm:"primaryKey;column:key"`
+	RequestHash string    `gorm:"column:request_hash"`
+	Response    []byte    `gorm:"column:response"`
+	Completed   bool      `gorm:"column:completed"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	ExpiresAt   time.Time `gorm:"column:expires_at"`
+}
+
+func (IdempotencyRecord) TableName() string {
+	return "idempotency_keys"
+}
+
 type CreateLotRequest struct {
+	SellerID       string
 	Name           string
 	Description    string
-	StartPrice     float64
+	StartPrice     money.Money
 	DurationMinute int64
+
+	SoftCloseWindowMinutes    int64
+	SoftCloseExtensionMinutes int64
+	ReservePrice              money.Money
+	BuyNowPrice               money.Money
+	BidIncrement              *IncrementRule
+	RelistedFromID            string
+
+	// Торги начнутся в StartTimeUnix; 0 или прошедшее время — сразу
+	StartTimeUnix int64
+	// Лот создаётся черновиком и ждёт публикации
+	Draft bool
 }
 
 type CreateLotResponse struct {
@@ -53,14 +133,57 @@ type GetLotResponse struct {
 	Lot Lot
 }
 
+type ListLotsRequest struct {
+	// Лот подходит, если его статус входит в список; пустой список — любой статус
+	Statuses []LotStatus
+	// Черновики видны только их продавцу (ViewerID) и администратору
+	ViewerID         string
+	ViewerAdmin      bool
+	MinPrice         money.Money
+	MaxPrice         money.Money
+	EndingAfterUnix  int64
+	EndingBeforeUnix int64
+	Query            string
+	SortBy           string
+	Descending       bool
+	PageSize         int
+	PageToken        string
+}
+
+type ListLotsResponse struct {
+	Lots          []Lot
+	NextPageToken string
+}
+
+type ListBidsRequest struct {
+	Lot_id    string
+	PageSize  int
+	PageToken string
+}
+
+type ListBidsResponse struct {
+	Currency      string
+	SellerID      string
+	Bids          []Bid
+	NextPageToken string
+	TotalBids     int64
+	UniqueBidders int64
+}
+
 type PlaceBidRequest struct {
-	Lot_id  string
-	User_id string
-	Amount  float64
+	Lot_id     string
+	User_id    string
+	Amount     money.Money
+	Max_amount money.Money
 }
 
 type PlaceBidResponse struct {
-	Success     bool
 	Message     string
 	Updated_lot Lot
 }
+
+type CreateUserRequest struct {
+	Username     string
+	PasswordHash string
+	Role         string
+}
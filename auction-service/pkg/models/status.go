@@ -0,0 +1,79 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// LotStatus — состояние лота. Значения совпадают с именами LotStatus
+// в proto без префикса LOT_STATUS_.
+type LotStatus string
+
+const (
+	StatusDraft     LotStatus = "DRAFT"
+	StatusScheduled LotStatus = "SCHEDULED"
+	StatusActive    LotStatus = "ACTIVE"
+	StatusClosing   LotStatus = "CLOSING"
+	StatusSold      LotStatus = "SOLD"
+	StatusUnsold    LotStatus = "UNSOLD"
+	StatusCancelled LotStatus = "CANCELLED"
+)
+
+var lotStatuses = []LotStatus{
+	StatusDraft, StatusScheduled, StatusActive, StatusClosing,
+	StatusSold, StatusUnsold, StatusCancelled,
+}
+
+// legacyStatusNames — прежние имена статусов. До машины состояний закрытие
+// по времени записывало COMPLETED при любом итоге торгов; миграция 011 переводит
+// такие лоты в SOLD, если есть победитель, и в UNSOLD иначе. Поэтому фильтр
+// клиентов по прежнему имени охватывает оба статуса.
+var legacyStatusNames = map[string][]LotStatus{
+	"COMPLETED": {StatusSold, StatusUnsold},
+}
+
+var ErrInvalidTransition = errors.New("invalid lot status transition")
+
+// ParseLotStatus разбирает имя статуса без учёта регистра, включая прежние
+// имена; прежнему имени может соответствовать несколько статусов.
+func ParseLotStatus(s string) ([]LotStatus, bool) {
+	name := strings.ToUpper(s)
+	if statuses, ok := legacyStatusNames[name]; ok {
+		return statuses, true
+	}
+	status := LotStatus(name)
+	return []LotStatus{status}, slices.Contains(lotStatuses, status)
+}
+
+// lotTransitions — единственный источник допустимых переходов между статусами.
+// Статусы без исходящих переходов конечны.
+var lotTransitions = map[LotStatus][]LotStatus{
+	StatusDraft:     {StatusScheduled, StatusActive, StatusCancelled},
+	StatusScheduled: {StatusActive, StatusCancelled},
+	// Мгновенная покупка продаёт лот, минуя CLOSING
+	StatusActive:  {StatusClosing, StatusSold, StatusCancelled},
+	StatusClosing: {StatusSold, StatusUnsold},
+}
+
+// CanTransitionTo сообщает, допустим ли переход из s в next.
+func (s LotStatus) CanTransitionTo(next LotStatus) bool {
+	return slices.Contains(lotTransitions[s], next)
+}
+
+// IsTerminal сообщает, что лот больше не изменит статус.
+func (s LotStatus) IsTerminal() bool {
+	return len(lotTransitions[s]) == 0
+}
+
+// TransitionTo переводит лот в статус next или возвращает ErrInvalidTransition.
+func (l *Lot) TransitionTo(next LotStatus, now time.Time) error {
+	if !l.Status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, l.Status, next)
+	}
+	l.Status = next
+	l.UpdatedAt = now
+	return nil
+}
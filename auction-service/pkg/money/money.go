@@ -0,0 +1,127 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency используется, если валюта в запросе не указана
+const DefaultCurrency = "RUB"
+
+// Money — денежная сумма в минимальных единицах валюты.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// exponents — число знаков после запятой в поддерживаемых валютах ISO 4217
+var exponents = map[string]int{
+	"RUB": 2,
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"CNY": 2,
+	"KZT": 2,
+	"BYN": 2,
+	"CHF": 2,
+	"JPY": 0,
+	"KWD": 3,
+}
+
+var (
+	ErrUnknownCurrency = errors.New("unknown currency")
+	ErrNegativeAmount  = errors.New("amount must not be negative")
+	ErrInvalidAmount   = errors.New("invalid amount")
+	ErrSubMinorUnit    = errors.New("amount is more precise than the currency minor unit")
+)
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Exponent возвращает число знаков после запятой для валюты.
+func Exponent(currency string) (int, error) {
+	exp, ok := exponents[currency]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+	return exp, nil
+}
+
+// MajorUnit возвращает количество минимальных единиц в одной основной (100 копеек в рубле).
+func MajorUnit(currency string) int64 {
+	exp, err := Exponent(currency)
+	if err != nil {
+		return 1
+	}
+
+	unit := int64(1)
+	for range exp {
+		unit *= 10
+	}
+	return unit
+}
+
+// Parse разбирает десятичную запись суммы, например "1500.50".
+// Сумма точнее минимальной единицы валюты ("10.005" для RUB) отклоняется.
+func Parse(amount, currency string) (Money, error) {
+	exp, err := Exponent(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	amount = strings.TrimSpace(amount)
+	if strings.HasPrefix(amount, "-") {
+		return Money{}, ErrNegativeAmount
+	}
+
+	whole, frac, _ := strings.Cut(amount, ".")
+	if whole == "" || strings.ContainsAny(whole+frac, "+-") {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+
+	trimmed := strings.TrimRight(frac, "0")
+	if len(trimmed) > exp {
+		return Money{}, fmt.Errorf("%w: %q %s", ErrSubMinorUnit, amount, currency)
+	}
+	frac = trimmed + strings.Repeat("0", exp-len(trimmed))
+
+	minor, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+
+	return Money{Amount: minor, Currency: currency}, nil
+}
+
+// Validate проверяет, что валюта поддерживается, а сумма неотрицательна.
+func (m Money) Validate() error {
+	if _, err := Exponent(m.Currency); err != nil {
+		return err
+	}
+	if m.Amount < 0 {
+		return ErrNegativeAmount
+	}
+	return nil
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) String() string {
+	exp, err := Exponent(m.Currency)
+	if err != nil || exp == 0 {
+		return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+	}
+
+	unit := MajorUnit(m.Currency)
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/unit, exp, amount%unit, m.Currency)
+}
@@ -7,9 +7,12 @@
 package auction
 
 import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,23 +25,288 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Жизненный цикл лота:
+// DRAFT → SCHEDULED → ACTIVE → CLOSING → SOLD | UNSOLD,
+// до завершения торгов лот можно перевести в CANCELLED.
+type LotStatus int32
+
+const (
+	LotStatus_LOT_STATUS_UNSPECIFIED LotStatus = 0
+	// Черновик: не виден для ставок до публикации
+	LotStatus_LOT_STATUS_DRAFT LotStatus = 1
+	// Опубликован, торги начнутся в start_time_unix
+	LotStatus_LOT_STATUS_SCHEDULED LotStatus = 2
+	LotStatus_LOT_STATUS_ACTIVE    LotStatus = 3
+	// Время вышло, ставки не принимаются, итог подводится
+	LotStatus_LOT_STATUS_CLOSING   LotStatus = 4
+	LotStatus_LOT_STATUS_SOLD      LotStatus = 5
+	LotStatus_LOT_STATUS_UNSOLD    LotStatus = 6
+	LotStatus_LOT_STATUS_CANCELLED LotStatus = 7
+)
+
+// Enum value maps for LotStatus.
+var (
+	LotStatus_name = map[int32]string{
+		0: "LOT_STATUS_UNSPECIFIED",
+		1: "LOT_STATUS_DRAFT",
+		2: "LOT_STATUS_SCHEDULED",
+		3: "LOT_STATUS_ACTIVE",
+		4: "LOT_STATUS_CLOSING",
+		5: "LOT_STATUS_SOLD",
+		6: "LOT_STATUS_UNSOLD",
+		7: "LOT_STATUS_CANCELLED",
+	}
+	LotStatus_value = map[string]int32{
+		"LOT_STATUS_UNSPECIFIED": 0,
+		"LOT_STATUS_DRAFT":       1,
+		"LOT_STATUS_SCHEDULED":   2,
+		"LOT_STATUS_ACTIVE":      3,
+		"LOT_STATUS_CLOSING":     4,
+		"LOT_STATUS_SOLD":        5,
+		"LOT_STATUS_UNSOLD":      6,
+		"LOT_STATUS_CANCELLED":   7,
+	}
+)
+
+func (x LotStatus) Enum() *LotStatus {
+	p := new(LotStatus)
+	*p = x
+	return p
+}
+
+func (x LotStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LotStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_auction_auction_proto_enumTypes[0].Descriptor()
+}
+
+func (LotStatus) Type() protoreflect.EnumType {
+	return &file_auction_auction_proto_enumTypes[0]
+}
+
+func (x LotStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LotStatus.Descriptor instead.
+func (LotStatus) EnumDescriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{0}
+}
+
+// Денежная сумма в минимальных единицах валюты (копейки, центы)
+type Money struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Код валюты ISO 4217, например RUB
+	CurrencyCode  string `protobuf:"bytes,1,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	MinorUnits    int64  `protobuf:"varint,2,opt,name=minor_units,json=minorUnits,proto3" json:"minor_units,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_auction_auction_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *Money) GetMinorUnits() int64 {
+	if x != nil {
+		return x.MinorUnits
+	}
+	return 0
+}
+
+// Правило минимального шага ставки. Суммы — в минимальных единицах валюты лота.
+type BidIncrement struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// fixed, percent или tiered
+	Type            string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	FixedMinorUnits int64  `protobuf:"varint,2,opt,name=fixed_minor_units,json=fixedMinorUnits,proto3" json:"fixed_minor_units,omitempty"`
+	// Процент от текущей цены в базисных пунктах: 250 = 2.5%
+	PercentBps int64 `protobuf:"varint,3,opt,name=percent_bps,json=percentBps,proto3" json:"percent_bps,omitempty"`
+	// Шаг по ценовым диапазонам, по возрастанию from_minor_units; первый начинается с 0
+	Tiers         []*BidIncrementTier `protobuf:"bytes,4,rep,name=tiers,proto3" json:"tiers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BidIncrement) Reset() {
+	*x = BidIncrement{}
+	mi := &file_auction_auction_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BidIncrement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BidIncrement) ProtoMessage() {}
+
+func (x *BidIncrement) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BidIncrement.ProtoReflect.Descriptor instead.
+func (*BidIncrement) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BidIncrement) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *BidIncrement) GetFixedMinorUnits() int64 {
+	if x != nil {
+		return x.FixedMinorUnits
+	}
+	return 0
+}
+
+func (x *BidIncrement) GetPercentBps() int64 {
+	if x != nil {
+		return x.PercentBps
+	}
+	return 0
+}
+
+func (x *BidIncrement) GetTiers() []*BidIncrementTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+type BidIncrementTier struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	FromMinorUnits      int64                  `protobuf:"varint,1,opt,name=from_minor_units,json=fromMinorUnits,proto3" json:"from_minor_units,omitempty"`
+	IncrementMinorUnits int64                  `protobuf:"varint,2,opt,name=increment_minor_units,json=incrementMinorUnits,proto3" json:"increment_minor_units,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *BidIncrementTier) Reset() {
+	*x = BidIncrementTier{}
+	mi := &file_auction_auction_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BidIncrementTier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BidIncrementTier) ProtoMessage() {}
+
+func (x *BidIncrementTier) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BidIncrementTier.ProtoReflect.Descriptor instead.
+func (*BidIncrementTier) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BidIncrementTier) GetFromMinorUnits() int64 {
+	if x != nil {
+		return x.FromMinorUnits
+	}
+	return 0
+}
+
+func (x *BidIncrementTier) GetIncrementMinorUnits() int64 {
+	if x != nil {
+		return x.IncrementMinorUnits
+	}
+	return 0
+}
+
 type Lot struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	StartPrice    float64                `protobuf:"fixed64,4,opt,name=startPrice,proto3" json:"startPrice,omitempty"`
-	CurrentPrice  float64                `protobuf:"fixed64,5,opt,name=currentPrice,proto3" json:"currentPrice,omitempty"`
+	StartPrice    *Money                 `protobuf:"bytes,13,opt,name=startPrice,proto3" json:"startPrice,omitempty"`
+	CurrentPrice  *Money                 `protobuf:"bytes,14,opt,name=currentPrice,proto3" json:"currentPrice,omitempty"`
 	CurrentWinner string                 `protobuf:"bytes,6,opt,name=currentWinner,proto3" json:"currentWinner,omitempty"`
-	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Status        LotStatus              `protobuf:"varint,21,opt,name=status,proto3,enum=auction.LotStatus" json:"status,omitempty"`
+	StartTimeUnix int64                  `protobuf:"varint,22,opt,name=start_time_unix,json=startTimeUnix,proto3" json:"start_time_unix,omitempty"`
 	EndTimeUnix   int64                  `protobuf:"varint,8,opt,name=end_time_unix,json=endTimeUnix,proto3" json:"end_time_unix,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// Анти-снайпинг: ставка в последние soft_close_window_minutes минут
+	// продлевает аукцион на soft_close_extension_minutes минут
+	SoftCloseWindowMinutes    int64 `protobuf:"varint,9,opt,name=soft_close_window_minutes,json=softCloseWindowMinutes,proto3" json:"soft_close_window_minutes,omitempty"`
+	SoftCloseExtensionMinutes int64 `protobuf:"varint,10,opt,name=soft_close_extension_minutes,json=softCloseExtensionMinutes,proto3" json:"soft_close_extension_minutes,omitempty"`
+	// Резервная цена скрыта, клиенту сообщается только, достигнута ли она
+	ReserveMet   bool          `protobuf:"varint,11,opt,name=reserve_met,json=reserveMet,proto3" json:"reserve_met,omitempty"`
+	BuyNowPrice  *Money        `protobuf:"bytes,15,opt,name=buy_now_price,json=buyNowPrice,proto3" json:"buy_now_price,omitempty"`
+	BidIncrement *BidIncrement `protobuf:"bytes,16,opt,name=bid_increment,json=bidIncrement,proto3" json:"bid_increment,omitempty"`
+	// Минимальная сумма, которую примет PlaceBid
+	NextMinBid *Money `protobuf:"bytes,17,opt,name=next_min_bid,json=nextMinBid,proto3" json:"next_min_bid,omitempty"`
+	// Продавец, создавший лот
+	SellerId string `protobuf:"bytes,18,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	// Причина отмены для лотов в статусе CANCELLED
+	CancelReason string `protobuf:"bytes,19,opt,name=cancel_reason,json=cancelReason,proto3" json:"cancel_reason,omitempty"`
+	// Лот, повторно выставленный этим лотом через RelistLot
+	RelistedFromId string `protobuf:"bytes,20,opt,name=relisted_from_id,json=relistedFromId,proto3" json:"relisted_from_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Lot) Reset() {
 	*x = Lot{}
-	mi := &file_auction_auction_proto_msgTypes[0]
+	mi := &file_auction_auction_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -50,7 +318,7 @@ func (x *Lot) String() string {
 func (*Lot) ProtoMessage() {}
 
 func (x *Lot) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_auction_proto_msgTypes[0]
+	mi := &file_auction_auction_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -63,7 +331,7 @@ func (x *Lot) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Lot.ProtoReflect.Descriptor instead.
 func (*Lot) Descriptor() ([]byte, []int) {
-	return file_auction_auction_proto_rawDescGZIP(), []int{0}
+	return file_auction_auction_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Lot) GetId() string {
@@ -87,67 +355,1310 @@ func (x *Lot) GetDescription() string {
 	return ""
 }
 
-func (x *Lot) GetStartPrice() float64 {
+func (x *Lot) GetStartPrice() *Money {
 	if x != nil {
 		return x.StartPrice
 	}
-	return 0
+	return nil
 }
 
-func (x *Lot) GetCurrentPrice() float64 {
+func (x *Lot) GetCurrentPrice() *Money {
 	if x != nil {
 		return x.CurrentPrice
 	}
-	return 0
+	return nil
 }
 
 func (x *Lot) GetCurrentWinner() string {
 	if x != nil {
-		return x.CurrentWinner
+		return x.CurrentWinner
+	}
+	return ""
+}
+
+func (x *Lot) GetStatus() LotStatus {
+	if x != nil {
+		return x.Status
+	}
+	return LotStatus_LOT_STATUS_UNSPECIFIED
+}
+
+func (x *Lot) GetStartTimeUnix() int64 {
+	if x != nil {
+		return x.StartTimeUnix
+	}
+	return 0
+}
+
+func (x *Lot) GetEndTimeUnix() int64 {
+	if x != nil {
+		return x.EndTimeUnix
+	}
+	return 0
+}
+
+func (x *Lot) GetSoftCloseWindowMinutes() int64 {
+	if x != nil {
+		return x.SoftCloseWindowMinutes
+	}
+	return 0
+}
+
+func (x *Lot) GetSoftCloseExtensionMinutes() int64 {
+	if x != nil {
+		return x.SoftCloseExtensionMinutes
+	}
+	return 0
+}
+
+func (x *Lot) GetReserveMet() bool {
+	if x != nil {
+		return x.ReserveMet
+	}
+	return false
+}
+
+func (x *Lot) GetBuyNowPrice() *Money {
+	if x != nil {
+		return x.BuyNowPrice
+	}
+	return nil
+}
+
+func (x *Lot) GetBidIncrement() *BidIncrement {
+	if x != nil {
+		return x.BidIncrement
+	}
+	return nil
+}
+
+func (x *Lot) GetNextMinBid() *Money {
+	if x != nil {
+		return x.NextMinBid
+	}
+	return nil
+}
+
+func (x *Lot) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *Lot) GetCancelReason() string {
+	if x != nil {
+		return x.CancelReason
+	}
+	return ""
+}
+
+func (x *Lot) GetRelistedFromId() string {
+	if x != nil {
+		return x.RelistedFromId
+	}
+	return ""
+}
+
+type Bid struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	LotId         string                 `protobuf:"bytes,2,opt,name=lot_id,json=lotId,proto3" json:"lot_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TimestampUnix int64                  `protobuf:"varint,5,opt,name=timestamp_unix,json=timestampUnix,proto3" json:"timestamp_unix,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bid) Reset() {
+	*x = Bid{}
+	mi := &file_auction_auction_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bid) ProtoMessage() {}
+
+func (x *Bid) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bid.ProtoReflect.Descriptor instead.
+func (*Bid) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Bid) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Bid) GetLotId() string {
+	if x != nil {
+		return x.LotId
+	}
+	return ""
+}
+
+func (x *Bid) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Bid) GetTimestampUnix() int64 {
+	if x != nil {
+		return x.TimestampUnix
+	}
+	return 0
+}
+
+func (x *Bid) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+// Сообщения для CRUD операций с лотами. Создавать лоты могут продавцы и
+// администраторы; продавцом лота становится автор запроса.
+type CreateLotRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Валюта стартовой цены становится валютой лота
+	StartPrice                *Money `protobuf:"bytes,9,opt,name=startPrice,proto3" json:"startPrice,omitempty"`
+	DurationMinute            int64  `protobuf:"varint,4,opt,name=durationMinute,proto3" json:"durationMinute,omitempty"`
+	SoftCloseWindowMinutes    int64  `protobuf:"varint,5,opt,name=soft_close_window_minutes,json=softCloseWindowMinutes,proto3" json:"soft_close_window_minutes,omitempty"`
+	SoftCloseExtensionMinutes int64  `protobuf:"varint,6,opt,name=soft_close_extension_minutes,json=softCloseExtensionMinutes,proto3" json:"soft_close_extension_minutes,omitempty"`
+	// Минимальная цена продажи; если к концу аукциона она не достигнута, лот не продаётся
+	ReservePrice *Money `protobuf:"bytes,10,opt,name=reserve_price,json=reservePrice,proto3" json:"reserve_price,omitempty"`
+	// Ставка или максимум автоматической ставки не ниже этой цены сразу
+	// завершает аукцион продажей по этой цене. Должна быть выше startPrice
+	// и не ниже reserve_price.
+	BuyNowPrice *Money `protobuf:"bytes,11,opt,name=buy_now_price,json=buyNowPrice,proto3" json:"buy_now_price,omitempty"`
+	// Если не задано, используется правило по умолчанию из конфигурации сервиса
+	BidIncrement *BidIncrement `protobuf:"bytes,12,opt,name=bid_increment,json=bidIncrement,proto3" json:"bid_increment,omitempty"`
+	// Время начала торгов; если не задано или уже прошло, лот открывается сразу
+	StartTimeUnix int64 `protobuf:"varint,13,opt,name=start_time_unix,json=startTimeUnix,proto3" json:"start_time_unix,omitempty"`
+	// Создать черновик, который станет доступен после PublishLot
+	Draft bool `protobuf:"varint,14,opt,name=draft,proto3" json:"draft,omitempty"`
+	// Повтор запроса с тем же ключом возвращает исходный ответ, а не создаёт
+	// второй лот. Можно передать и заголовком Idempotency-Key.
+	IdempotencyKey string `protobuf:"bytes,15,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateLotRequest) Reset() {
+	*x = CreateLotRequest{}
+	mi := &file_auction_auction_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLotRequest) ProtoMessage() {}
+
+func (x *CreateLotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLotRequest.ProtoReflect.Descriptor instead.
+func (*CreateLotRequest) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateLotRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateLotRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateLotRequest) GetStartPrice() *Money {
+	if x != nil {
+		return x.StartPrice
+	}
+	return nil
+}
+
+func (x *CreateLotRequest) GetDurationMinute() int64 {
+	if x != nil {
+		return x.DurationMinute
+	}
+	return 0
+}
+
+func (x *CreateLotRequest) GetSoftCloseWindowMinutes() int64 {
+	if x != nil {
+		return x.SoftCloseWindowMinutes
+	}
+	return 0
+}
+
+func (x *CreateLotRequest) GetSoftCloseExtensionMinutes() int64 {
+	if x != nil {
+		return x.SoftCloseExtensionMinutes
+	}
+	return 0
+}
+
+func (x *CreateLotRequest) GetReservePrice() *Money {
+	if x != nil {
+		return x.ReservePrice
+	}
+	return nil
+}
+
+func (x *CreateLotRequest) GetBuyNowPrice() *Money {
+	if x != nil {
+		return x.BuyNowPrice
+	}
+	return nil
+}
+
+func (x *CreateLotRequest) GetBidIncrement() *BidIncrement {
+	if x != nil {
+		return x.BidIncrement
+	}
+	return nil
+}
+
+func (x *CreateLotRequest) GetStartTimeUnix() int64 {
+	if x != nil {
+		return x.StartTimeUnix
+	}
+	return 0
+}
+
+func (x *CreateLotRequest) GetDraft() bool {
+	if x != nil {
+		return x.Draft
+	}
+	return false
+}
+
+func (x *CreateLotRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type CreateLotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lot           *Lot                   `protobuf:"bytes,1,opt,name=lot,proto3" json:"lot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLotResponse) Reset() {
+	*x = CreateLotResponse{}
+	mi := &file_auction_auction_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLotResponse) ProtoMessage() {}
+
+func (x *CreateLotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLotResponse.ProtoReflect.Descriptor instead.
+func (*CreateLotResponse) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateLotResponse) GetLot() *Lot {
+	if x != nil {
+		return x.Lot
+	}
+	return nil
+}
+
+type GetLotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LotId         string                 `protobuf:"bytes,1,opt,name=lot_id,json=lotId,proto3" json:"lot_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLotRequest) Reset() {
+	*x = GetLotRequest{}
+	mi := &file_auction_auction_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLotRequest) ProtoMessage() {}
+
+func (x *GetLotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLotRequest.ProtoReflect.Descriptor instead.
+func (*GetLotRequest) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetLotRequest) GetLotId() string {
+	if x != nil {
+		return x.LotId
+	}
+	return ""
+}
+
+type GetLotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lot           *Lot                   `protobuf:"bytes,1,opt,name=lot,proto3" json:"lot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLotResponse) Reset() {
+	*x = GetLotResponse{}
+	mi := &file_auction_auction_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLotResponse) ProtoMessage() {}
+
+func (x *GetLotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLotResponse.ProtoReflect.Descriptor instead.
+func (*GetLotResponse) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetLotResponse) GetLot() *Lot {
+	if x != nil {
+		return x.Lot
+	}
+	return nil
+}
+
+// Изменение лота владельцем или администратором. Допустимо для лота
+// в статусе DRAFT, SCHEDULED или ACTIVE без ставок.
+type UpdateLotRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	LotId       string                 `protobuf:"bytes,1,opt,name=lot_id,json=lotId,proto3" json:"lot_id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// На сколько минут продлить аукцион; срок можно только увеличить,
+	// за один вызов не больше, чем длительность нового лота
+	ExtendMinutes int64 `protobuf:"varint,4,opt,name=extend_minutes,json=extendMinutes,proto3" json:"extend_minutes,omitempty"`
+	// Обязательна: name, description, extend_minutes
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,5,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLotRequest) Reset() {
+	*x = UpdateLotRequest{}
+	mi := &file_auction_auction_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLotRequest) ProtoMessage() {}
+
+func (x *UpdateLotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLotRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLotRequest) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateLotRequest) GetLotId() string {
+	if x != nil {
+		return x.LotId
+	}
+	return ""
+}
+
+func (x *UpdateLotRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateLotRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateLotRequest) GetExtendMinutes() int64 {
+	if x != nil {
+		return x.ExtendMinutes
+	}
+	return 0
+}
+
+func (x *UpdateLotRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateLotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lot           *Lot                   `protobuf:"bytes,1,opt,name=lot,proto3" json:"lot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLotResponse) Reset() {
+	*x = UpdateLotResponse{}
+	mi := &file_auction_auction_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLotResponse) ProtoMessage() {}
+
+func (x *UpdateLotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLotResponse.ProtoReflect.Descriptor instead.
+func (*UpdateLotResponse) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateLotResponse) GetLot() *Lot {
+	if x != nil {
+		return x.Lot
+	}
+	return nil
+}
+
+// Снятие лота с торгов до их завершения. Подписчики получают финальное состояние
+// лота, участники торгов — уведомление.
+type CancelLotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LotId         string                 `protobuf:"bytes,1,opt,name=lot_id,json=lotId,proto3" json:"lot_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelLotRequest) Reset() {
+	*x = CancelLotRequest{}
+	mi := &file_auction_auction_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelLotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelLotRequest) ProtoMessage() {}
+
+func (x *CancelLotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelLotRequest.ProtoReflect.Descriptor instead.
+func (*CancelLotRequest) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelLotRequest) GetLotId() string {
+	if x != nil {
+		return x.LotId
+	}
+	return ""
+}
+
+func (x *CancelLotRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelLotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lot           *Lot                   `protobuf:"bytes,1,opt,name=lot,proto3" json:"lot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelLotResponse) Reset() {
+	*x = CancelLotResponse{}
+	mi := &file_auction_auction_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelLotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelLotResponse) ProtoMessage() {}
+
+func (x *CancelLotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelLotResponse.ProtoReflect.Descriptor instead.
+func (*CancelLotResponse) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CancelLotResponse) GetLot() *Lot {
+	if x != nil {
+		return x.Lot
+	}
+	return nil
+}
+
+// Публикация черновика: лот становится SCHEDULED или сразу ACTIVE,
+// если время начала уже наступило.
+type PublishLotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LotId         string                 `protobuf:"bytes,1,opt,name=lot_id,json=lotId,proto3" json:"lot_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishLotRequest) Reset() {
+	*x = PublishLotRequest{}
+	mi := &file_auction_auction_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishLotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishLotRequest) ProtoMessage() {}
+
+func (x *PublishLotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishLotRequest.ProtoReflect.Descriptor instead.
+func (*PublishLotRequest) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PublishLotRequest) GetLotId() string {
+	if x != nil {
+		return x.LotId
+	}
+	return ""
+}
+
+type PublishLotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lot           *Lot                   `protobuf:"bytes,1,opt,name=lot,proto3" json:"lot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishLotResponse) Reset() {
+	*x = PublishLotResponse{}
+	mi := &file_auction_auction_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishLotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishLotResponse) ProtoMessage() {}
+
+func (x *PublishLotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishLotResponse.ProtoReflect.Descriptor instead.
+func (*PublishLotResponse) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PublishLotResponse) GetLot() *Lot {
+	if x != nil {
+		return x.Lot
+	}
+	return nil
+}
+
+// Повторное выставление лота в статусе UNSOLD или CANCELLED: создаётся
+// новый активный лот с теми же условиями. Каждый лот выставляется повторно один раз.
+type RelistLotRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	LotId string                 `protobuf:"bytes,1,opt,name=lot_id,json=lotId,proto3" json:"lot_id,omitempty"`
+	// По умолчанию — длительность исходного лота
+	DurationMinute int64 `protobuf:"varint,2,opt,name=durationMinute,proto3" json:"durationMinute,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RelistLotRequest) Reset() {
+	*x = RelistLotRequest{}
+	mi := &file_auction_auction_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelistLotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelistLotRequest) ProtoMessage() {}
+
+func (x *RelistLotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelistLotRequest.ProtoReflect.Descriptor instead.
+func (*RelistLotRequest) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RelistLotRequest) GetLotId() string {
+	if x != nil {
+		return x.LotId
+	}
+	return ""
+}
+
+func (x *RelistLotRequest) GetDurationMinute() int64 {
+	if x != nil {
+		return x.DurationMinute
+	}
+	return 0
+}
+
+type RelistLotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lot           *Lot                   `protobuf:"bytes,1,opt,name=lot,proto3" json:"lot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelistLotResponse) Reset() {
+	*x = RelistLotResponse{}
+	mi := &file_auction_auction_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelistLotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelistLotResponse) ProtoMessage() {}
+
+func (x *RelistLotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelistLotResponse.ProtoReflect.Descriptor instead.
+func (*RelistLotResponse) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RelistLotResponse) GetLot() *Lot {
+	if x != nil {
+		return x.Lot
+	}
+	return nil
+}
+
+// Поиск лотов. Все фильтры необязательны и объединяются через AND.
+type ListLotsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Прежний строковый фильтр (ACTIVE, SOLD, ...); учитывается, только если
+	// status не задан. Оставлен на переходный период для старых gRPC-клиентов.
+	//
+	// Deprecated: Marked as deprecated in auction/auction.proto.
+	LegacyStatus     string    `protobuf:"bytes,1,opt,name=legacy_status,json=legacyStatus,proto3" json:"legacy_status,omitempty"`
+	Status           LotStatus `protobuf:"varint,13,opt,name=status,proto3,enum=auction.LotStatus" json:"status,omitempty"`
+	EndingAfterUnix  int64     `protobuf:"varint,4,opt,name=ending_after_unix,json=endingAfterUnix,proto3" json:"ending_after_unix,omitempty"`
+	EndingBeforeUnix int64     `protobuf:"varint,5,opt,name=ending_before_unix,json=endingBeforeUnix,proto3" json:"ending_before_unix,omitempty"`
+	// Подстрока в названии лота, без учёта регистра
+	Query string `protobuf:"bytes,6,opt,name=query,proto3" json:"query,omitempty"`
+	// end_time (по умолчанию), price или created_at
+	SortBy     string `protobuf:"bytes,7,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	Descending bool   `protobuf:"varint,8,opt,name=descending,proto3" json:"descending,omitempty"`
+	// По умолчанию 20, максимум 100
+	PageSize int32 `protobuf:"varint,9,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token из предыдущего ответа; фильтры и сортировка должны совпадать
+	PageToken string `protobuf:"bytes,10,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Фильтр по текущей цене; учитываются только лоты в валюте фильтра
+	MinPrice      *Money `protobuf:"bytes,11,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice      *Money `protobuf:"bytes,12,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLotsRequest) Reset() {
+	*x = ListLotsRequest{}
+	mi := &file_auction_auction_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLotsRequest) ProtoMessage() {}
+
+func (x *ListLotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLotsRequest.ProtoReflect.Descriptor instead.
+func (*ListLotsRequest) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{17}
+}
+
+// Deprecated: Marked as deprecated in auction/auction.proto.
+func (x *ListLotsRequest) GetLegacyStatus() string {
+	if x != nil {
+		return x.LegacyStatus
+	}
+	return ""
+}
+
+func (x *ListLotsRequest) GetStatus() LotStatus {
+	if x != nil {
+		return x.Status
+	}
+	return LotStatus_LOT_STATUS_UNSPECIFIED
+}
+
+func (x *ListLotsRequest) GetEndingAfterUnix() int64 {
+	if x != nil {
+		return x.EndingAfterUnix
+	}
+	return 0
+}
+
+func (x *ListLotsRequest) GetEndingBeforeUnix() int64 {
+	if x != nil {
+		return x.EndingBeforeUnix
+	}
+	return 0
+}
+
+func (x *ListLotsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListLotsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListLotsRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *ListLotsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListLotsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListLotsRequest) GetMinPrice() *Money {
+	if x != nil {
+		return x.MinPrice
+	}
+	return nil
+}
+
+func (x *ListLotsRequest) GetMaxPrice() *Money {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return nil
+}
+
+type ListLotsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Lots  []*Lot                 `protobuf:"bytes,1,rep,name=lots,proto3" json:"lots,omitempty"`
+	// Пустой, если страниц больше нет
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLotsResponse) Reset() {
+	*x = ListLotsResponse{}
+	mi := &file_auction_auction_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLotsResponse) ProtoMessage() {}
+
+func (x *ListLotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLotsResponse.ProtoReflect.Descriptor instead.
+func (*ListLotsResponse) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListLotsResponse) GetLots() []*Lot {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+func (x *ListLotsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Сообщение для размещения ставки
+type PlaceBidRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	LotId string                 `protobuf:"bytes,1,opt,name=lot_id,json=lotId,proto3" json:"lot_id,omitempty"`
+	// Необязателен: участник определяется по токену доступа.
+	// Если указан, должен совпадать с аутентифицированным пользователем.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Валюта ставки должна совпадать с валютой лота
+	Amount *Money `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Максимальная сумма автоматической ставки, скрыта от других участников.
+	// Если задана, система сама перебивает конкурентов с минимальным шагом.
+	MaxAmount *Money `protobuf:"bytes,6,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	// Повтор ставки с тем же ключом возвращает исходный ответ, а не ставит
+	// повторно. Можно передать и заголовком Idempotency-Key.
+	IdempotencyKey string `protobuf:"bytes,7,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PlaceBidRequest) Reset() {
+	*x = PlaceBidRequest{}
+	mi := &file_auction_auction_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceBidRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceBidRequest) ProtoMessage() {}
+
+func (x *PlaceBidRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceBidRequest.ProtoReflect.Descriptor instead.
+func (*PlaceBidRequest) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PlaceBidRequest) GetLotId() string {
+	if x != nil {
+		return x.LotId
+	}
+	return ""
+}
+
+func (x *PlaceBidRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PlaceBidRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *PlaceBidRequest) GetMaxAmount() *Money {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return nil
+}
+
+func (x *PlaceBidRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+// Отказ возвращается gRPC-ошибкой с google.rpc.ErrorInfo: reason LOT_NOT_FOUND,
+// LOT_NOT_ACTIVE, AUCTION_ENDED, BID_TOO_LOW, CURRENCY_MISMATCH и т.д.
+type PlaceBidResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Всегда true, оставлено для совместимости со старыми клиентами
+	//
+	// Deprecated: Marked as deprecated in auction/auction.proto.
+	Success       bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	UpdatedLot    *Lot   `protobuf:"bytes,3,opt,name=updated_lot,json=updatedLot,proto3" json:"updated_lot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceBidResponse) Reset() {
+	*x = PlaceBidResponse{}
+	mi := &file_auction_auction_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceBidResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceBidResponse) ProtoMessage() {}
+
+func (x *PlaceBidResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceBidResponse.ProtoReflect.Descriptor instead.
+func (*PlaceBidResponse) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{20}
+}
+
+// Deprecated: Marked as deprecated in auction/auction.proto.
+func (x *PlaceBidResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PlaceBidResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PlaceBidResponse) GetUpdatedLot() *Lot {
+	if x != nil {
+		return x.UpdatedLot
+	}
+	return nil
+}
+
+// История ставок по лоту в хронологическом порядке
+type ListBidsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	LotId string                 `protobuf:"bytes,1,opt,name=lot_id,json=lotId,proto3" json:"lot_id,omitempty"`
+	// По умолчанию 20, максимум 100
+	PageSize  int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Идентификаторы участников видят только продавец лота и администратор,
+	// остальным всегда возвращаются псевдонимы. Флаг скрывает их и для продавца
+	MaskBidders   bool `protobuf:"varint,4,opt,name=mask_bidders,json=maskBidders,proto3" json:"mask_bidders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBidsRequest) Reset() {
+	*x = ListBidsRequest{}
+	mi := &file_auction_auction_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBidsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBidsRequest) ProtoMessage() {}
+
+func (x *ListBidsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBidsRequest.ProtoReflect.Descriptor instead.
+func (*ListBidsRequest) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListBidsRequest) GetLotId() string {
+	if x != nil {
+		return x.LotId
+	}
+	return ""
+}
+
+func (x *ListBidsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
 	}
-	return ""
+	return 0
 }
 
-func (x *Lot) GetStatus() string {
+func (x *ListBidsRequest) GetPageToken() string {
 	if x != nil {
-		return x.Status
+		return x.PageToken
 	}
 	return ""
 }
 
-func (x *Lot) GetEndTimeUnix() int64 {
+func (x *ListBidsRequest) GetMaskBidders() bool {
 	if x != nil {
-		return x.EndTimeUnix
+		return x.MaskBidders
 	}
-	return 0
+	return false
 }
 
-// Сообщения для CRUD операций с лотами
-type CreateLotRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description    string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	StartPrice     float64                `protobuf:"fixed64,3,opt,name=startPrice,proto3" json:"startPrice,omitempty"`
-	DurationMinute int64                  `protobuf:"varint,4,opt,name=durationMinute,proto3" json:"durationMinute,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type ListBidsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bids          []*Bid                 `protobuf:"bytes,1,rep,name=bids,proto3" json:"bids,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalBids     int64                  `protobuf:"varint,3,opt,name=total_bids,json=totalBids,proto3" json:"total_bids,omitempty"`
+	UniqueBidders int64                  `protobuf:"varint,4,opt,name=unique_bidders,json=uniqueBidders,proto3" json:"unique_bidders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateLotRequest) Reset() {
-	*x = CreateLotRequest{}
-	mi := &file_auction_auction_proto_msgTypes[1]
+func (x *ListBidsResponse) Reset() {
+	*x = ListBidsResponse{}
+	mi := &file_auction_auction_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateLotRequest) String() string {
+func (x *ListBidsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateLotRequest) ProtoMessage() {}
+func (*ListBidsResponse) ProtoMessage() {}
 
-func (x *CreateLotRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_auction_proto_msgTypes[1]
+func (x *ListBidsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -158,61 +1669,62 @@ func (x *CreateLotRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateLotRequest.ProtoReflect.Descriptor instead.
-func (*CreateLotRequest) Descriptor() ([]byte, []int) {
-	return file_auction_auction_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use ListBidsResponse.ProtoReflect.Descriptor instead.
+func (*ListBidsResponse) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *CreateLotRequest) GetName() string {
+func (x *ListBidsResponse) GetBids() []*Bid {
 	if x != nil {
-		return x.Name
+		return x.Bids
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateLotRequest) GetDescription() string {
+func (x *ListBidsResponse) GetNextPageToken() string {
 	if x != nil {
-		return x.Description
+		return x.NextPageToken
 	}
 	return ""
 }
 
-func (x *CreateLotRequest) GetStartPrice() float64 {
+func (x *ListBidsResponse) GetTotalBids() int64 {
 	if x != nil {
-		return x.StartPrice
+		return x.TotalBids
 	}
 	return 0
 }
 
-func (x *CreateLotRequest) GetDurationMinute() int64 {
+func (x *ListBidsResponse) GetUniqueBidders() int64 {
 	if x != nil {
-		return x.DurationMinute
+		return x.UniqueBidders
 	}
 	return 0
 }
 
-type CreateLotResponse struct {
+// Сообщения остаются без изменений
+type SubscribeToLotRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Lot           *Lot                   `protobuf:"bytes,1,opt,name=lot,proto3" json:"lot,omitempty"`
+	LotId         string                 `protobuf:"bytes,1,opt,name=lot_id,json=lotId,proto3" json:"lot_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateLotResponse) Reset() {
-	*x = CreateLotResponse{}
-	mi := &file_auction_auction_proto_msgTypes[2]
+func (x *SubscribeToLotRequest) Reset() {
+	*x = SubscribeToLotRequest{}
+	mi := &file_auction_auction_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateLotResponse) String() string {
+func (x *SubscribeToLotRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateLotResponse) ProtoMessage() {}
+func (*SubscribeToLotRequest) ProtoMessage() {}
 
-func (x *CreateLotResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_auction_proto_msgTypes[2]
+func (x *SubscribeToLotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -223,40 +1735,40 @@ func (x *CreateLotResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateLotResponse.ProtoReflect.Descriptor instead.
-func (*CreateLotResponse) Descriptor() ([]byte, []int) {
-	return file_auction_auction_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use SubscribeToLotRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeToLotRequest) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *CreateLotResponse) GetLot() *Lot {
+func (x *SubscribeToLotRequest) GetLotId() string {
 	if x != nil {
-		return x.Lot
+		return x.LotId
 	}
-	return nil
+	return ""
 }
 
-type GetLotRequest struct {
+type SubscribeToLotResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	LotId         string                 `protobuf:"bytes,1,opt,name=lot_id,json=lotId,proto3" json:"lot_id,omitempty"`
+	Lot           *Lot                   `protobuf:"bytes,1,opt,name=lot,proto3" json:"lot,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetLotRequest) Reset() {
-	*x = GetLotRequest{}
-	mi := &file_auction_auction_proto_msgTypes[3]
+func (x *SubscribeToLotResponse) Reset() {
+	*x = SubscribeToLotResponse{}
+	mi := &file_auction_auction_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLotRequest) String() string {
+func (x *SubscribeToLotResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLotRequest) ProtoMessage() {}
+func (*SubscribeToLotResponse) ProtoMessage() {}
 
-func (x *GetLotRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_auction_proto_msgTypes[3]
+func (x *SubscribeToLotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -267,40 +1779,41 @@ func (x *GetLotRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLotRequest.ProtoReflect.Descriptor instead.
-func (*GetLotRequest) Descriptor() ([]byte, []int) {
-	return file_auction_auction_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use SubscribeToLotResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeToLotResponse) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *GetLotRequest) GetLotId() string {
+func (x *SubscribeToLotResponse) GetLot() *Lot {
 	if x != nil {
-		return x.LotId
+		return x.Lot
 	}
-	return ""
+	return nil
 }
 
-type GetLotResponse struct {
+// Личные уведомления аутентифицированного пользователя: например, об отмене
+// лота, на который он делал ставки. Пользователь берётся из токена.
+type SubscribeToNotificationsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Lot           *Lot                   `protobuf:"bytes,1,opt,name=lot,proto3" json:"lot,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetLotResponse) Reset() {
-	*x = GetLotResponse{}
-	mi := &file_auction_auction_proto_msgTypes[4]
+func (x *SubscribeToNotificationsRequest) Reset() {
+	*x = SubscribeToNotificationsRequest{}
+	mi := &file_auction_auction_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLotResponse) String() string {
+func (x *SubscribeToNotificationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLotResponse) ProtoMessage() {}
+func (*SubscribeToNotificationsRequest) ProtoMessage() {}
 
-func (x *GetLotResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_auction_proto_msgTypes[4]
+func (x *SubscribeToNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -311,43 +1824,91 @@ func (x *GetLotResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLotResponse.ProtoReflect.Descriptor instead.
-func (*GetLotResponse) Descriptor() ([]byte, []int) {
-	return file_auction_auction_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use SubscribeToNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeToNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *GetLotResponse) GetLot() *Lot {
+type SubscribeToNotificationsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Тип события: LOT_CANCELLED
+	Type          string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Lot           *Lot   `protobuf:"bytes,2,opt,name=lot,proto3" json:"lot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeToNotificationsResponse) Reset() {
+	*x = SubscribeToNotificationsResponse{}
+	mi := &file_auction_auction_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeToNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeToNotificationsResponse) ProtoMessage() {}
+
+func (x *SubscribeToNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeToNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeToNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SubscribeToNotificationsResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SubscribeToNotificationsResponse) GetLot() *Lot {
 	if x != nil {
 		return x.Lot
 	}
 	return nil
 }
 
-// Сообщение для размещения ставки
-type PlaceBidRequest struct {
+// Пользователи
+type User struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	LotId         string                 `protobuf:"bytes,1,opt,name=lot_id,json=lotId,proto3" json:"lot_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	CreatedAtUnix int64                  `protobuf:"varint,3,opt,name=created_at_unix,json=createdAtUnix,proto3" json:"created_at_unix,omitempty"`
+	// bidder, seller или admin
+	Role          string `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PlaceBidRequest) Reset() {
-	*x = PlaceBidRequest{}
-	mi := &file_auction_auction_proto_msgTypes[5]
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_auction_auction_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlaceBidRequest) String() string {
+func (x *User) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlaceBidRequest) ProtoMessage() {}
+func (*User) ProtoMessage() {}
 
-func (x *PlaceBidRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_auction_proto_msgTypes[5]
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -358,56 +1919,66 @@ func (x *PlaceBidRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlaceBidRequest.ProtoReflect.Descriptor instead.
-func (*PlaceBidRequest) Descriptor() ([]byte, []int) {
-	return file_auction_auction_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *PlaceBidRequest) GetLotId() string {
+func (x *User) GetId() string {
 	if x != nil {
-		return x.LotId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *PlaceBidRequest) GetUserId() string {
+func (x *User) GetUsername() string {
 	if x != nil {
-		return x.UserId
+		return x.Username
 	}
 	return ""
 }
 
-func (x *PlaceBidRequest) GetAmount() float64 {
+func (x *User) GetCreatedAtUnix() int64 {
 	if x != nil {
-		return x.Amount
+		return x.CreatedAtUnix
 	}
 	return 0
 }
 
-type PlaceBidResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	UpdatedLot    *Lot                   `protobuf:"bytes,3,opt,name=updated_lot,json=updatedLot,proto3" json:"updated_lot,omitempty"`
+func (x *User) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type RegisterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 3–32 символа: латинские буквы, цифры, '_', '.', '-'; регистр не учитывается
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// От 8 до 72 байт: bcrypt учитывает только первые 72
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	// bidder (по умолчанию) или seller; admin назначается только вручную
+	Role          string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PlaceBidResponse) Reset() {
-	*x = PlaceBidResponse{}
-	mi := &file_auction_auction_proto_msgTypes[6]
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	mi := &file_auction_auction_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlaceBidResponse) String() string {
+func (x *RegisterRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlaceBidResponse) ProtoMessage() {}
+func (*RegisterRequest) ProtoMessage() {}
 
-func (x *PlaceBidResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_auction_proto_msgTypes[6]
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -418,55 +1989,99 @@ func (x *PlaceBidResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlaceBidResponse.ProtoReflect.Descriptor instead.
-func (*PlaceBidResponse) Descriptor() ([]byte, []int) {
-	return file_auction_auction_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *PlaceBidResponse) GetSuccess() bool {
+func (x *RegisterRequest) GetUsername() string {
 	if x != nil {
-		return x.Success
+		return x.Username
 	}
-	return false
+	return ""
 }
 
-func (x *PlaceBidResponse) GetMessage() string {
+func (x *RegisterRequest) GetPassword() string {
 	if x != nil {
-		return x.Message
+		return x.Password
 	}
 	return ""
 }
 
-func (x *PlaceBidResponse) GetUpdatedLot() *Lot {
+func (x *RegisterRequest) GetRole() string {
 	if x != nil {
-		return x.UpdatedLot
+		return x.Role
+	}
+	return ""
+}
+
+type RegisterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterResponse) Reset() {
+	*x = RegisterResponse{}
+	mi := &file_auction_auction_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterResponse) ProtoMessage() {}
+
+func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterResponse.ProtoReflect.Descriptor instead.
+func (*RegisterResponse) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RegisterResponse) GetUser() *User {
+	if x != nil {
+		return x.User
 	}
 	return nil
 }
 
-// Сообщения остаются без изменений
-type SubscribeToLotRequest struct {
+type LoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	LotId         string                 `protobuf:"bytes,1,opt,name=lot_id,json=lotId,proto3" json:"lot_id,omitempty"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubscribeToLotRequest) Reset() {
-	*x = SubscribeToLotRequest{}
-	mi := &file_auction_auction_proto_msgTypes[7]
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_auction_auction_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubscribeToLotRequest) String() string {
+func (x *LoginRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubscribeToLotRequest) ProtoMessage() {}
+func (*LoginRequest) ProtoMessage() {}
 
-func (x *SubscribeToLotRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_auction_proto_msgTypes[7]
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -477,40 +2092,50 @@ func (x *SubscribeToLotRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubscribeToLotRequest.ProtoReflect.Descriptor instead.
-func (*SubscribeToLotRequest) Descriptor() ([]byte, []int) {
-	return file_auction_auction_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *SubscribeToLotRequest) GetLotId() string {
+func (x *LoginRequest) GetUsername() string {
 	if x != nil {
-		return x.LotId
+		return x.Username
 	}
 	return ""
 }
 
-type SubscribeToLotResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Lot           *Lot                   `protobuf:"bytes,1,opt,name=lot,proto3" json:"lot,omitempty"`
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type LoginResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// JWT; передаётся в заголовке Authorization: Bearer <token>
+	AccessToken   string `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	ExpiresAtUnix int64  `protobuf:"varint,2,opt,name=expires_at_unix,json=expiresAtUnix,proto3" json:"expires_at_unix,omitempty"`
+	User          *User  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubscribeToLotResponse) Reset() {
-	*x = SubscribeToLotResponse{}
-	mi := &file_auction_auction_proto_msgTypes[8]
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_auction_auction_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubscribeToLotResponse) String() string {
+func (x *LoginResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubscribeToLotResponse) ProtoMessage() {}
+func (*LoginResponse) ProtoMessage() {}
 
-func (x *SubscribeToLotResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_auction_proto_msgTypes[8]
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_auction_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -521,14 +2146,28 @@ func (x *SubscribeToLotResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubscribeToLotResponse.ProtoReflect.Descriptor instead.
-func (*SubscribeToLotResponse) Descriptor() ([]byte, []int) {
-	return file_auction_auction_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_auction_auction_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *SubscribeToLotResponse) GetLot() *Lot {
+func (x *LoginResponse) GetAccessToken() string {
 	if x != nil {
-		return x.Lot
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetExpiresAtUnix() int64 {
+	if x != nil {
+		return x.ExpiresAtUnix
+	}
+	return 0
+}
+
+func (x *LoginResponse) GetUser() *User {
+	if x != nil {
+		return x.User
 	}
 	return nil
 }
@@ -537,49 +2176,191 @@ var File_auction_auction_proto protoreflect.FileDescriptor
 
 const file_auction_auction_proto_rawDesc = "" +
 	"\n" +
-	"\x15auction/auction.proto\x12\aauction\x1a\x1cgoogle/api/annotations.proto\"\xf1\x01\n" +
+	"\x15auction/auction.proto\x12\aauction\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a google/protobuf/field_mask.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"|\n" +
+	"\x05Money\x12I\n" +
+	"\rcurrency_code\x18\x01 \x01(\tB$\x92A\r\x8a\x01\n" +
+	"^[A-Z]{3}$\xbaH\x11\xd8\x01\x01r\f2\n" +
+	"^[A-Z]{3}$R\fcurrencyCode\x12(\n" +
+	"\vminor_units\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\n" +
+	"minorUnits\"\xfe\x01\n" +
+	"\fBidIncrement\x12O\n" +
+	"\x04type\x18\x01 \x01(\tB;\x92A\x1b\xf2\x02\x05fixed\xf2\x02\apercent\xf2\x02\x06tiered\xbaH\x1ar\x18R\x05fixedR\apercentR\x06tieredR\x04type\x123\n" +
+	"\x11fixed_minor_units\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x0ffixedMinorUnits\x127\n" +
+	"\vpercent_bps\x18\x03 \x01(\x03B\x16\x92A\tY\x00\x00\x00\x00\x00\x88\xc3@\xbaH\a\"\x05\x18\x90N(\x00R\n" +
+	"percentBps\x12/\n" +
+	"\x05tiers\x18\x04 \x03(\v2\x19.auction.BidIncrementTierR\x05tiers\"\x8e\x01\n" +
+	"\x10BidIncrementTier\x121\n" +
+	"\x10from_minor_units\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x0efromMinorUnits\x12G\n" +
+	"\x15increment_minor_units\x18\x02 \x01(\x03B\x13\x92A\ti\x00\x00\x00\x00\x00\x00\xf0?\xbaH\x04\"\x02 \x00R\x13incrementMinorUnits\"\x90\x06\n" +
 	"\x03Lot\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1e\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12.\n" +
 	"\n" +
-	"startPrice\x18\x04 \x01(\x01R\n" +
-	"startPrice\x12\"\n" +
-	"\fcurrentPrice\x18\x05 \x01(\x01R\fcurrentPrice\x12$\n" +
-	"\rcurrentWinner\x18\x06 \x01(\tR\rcurrentWinner\x12\x16\n" +
-	"\x06status\x18\a \x01(\tR\x06status\x12\"\n" +
-	"\rend_time_unix\x18\b \x01(\x03R\vendTimeUnix\"\x90\x01\n" +
-	"\x10CreateLotRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1e\n" +
+	"startPrice\x18\r \x01(\v2\x0e.auction.MoneyR\n" +
+	"startPrice\x122\n" +
+	"\fcurrentPrice\x18\x0e \x01(\v2\x0e.auction.MoneyR\fcurrentPrice\x12$\n" +
+	"\rcurrentWinner\x18\x06 \x01(\tR\rcurrentWinner\x12*\n" +
+	"\x06status\x18\x15 \x01(\x0e2\x12.auction.LotStatusR\x06status\x12&\n" +
+	"\x0fstart_time_unix\x18\x16 \x01(\x03R\rstartTimeUnix\x12\"\n" +
+	"\rend_time_unix\x18\b \x01(\x03R\vendTimeUnix\x129\n" +
+	"\x19soft_close_window_minutes\x18\t \x01(\x03R\x16softCloseWindowMinutes\x12?\n" +
+	"\x1csoft_close_extension_minutes\x18\n" +
+	" \x01(\x03R\x19softCloseExtensionMinutes\x12\x1f\n" +
+	"\vreserve_met\x18\v \x01(\bR\n" +
+	"reserveMet\x122\n" +
+	"\rbuy_now_price\x18\x0f \x01(\v2\x0e.auction.MoneyR\vbuyNowPrice\x12:\n" +
+	"\rbid_increment\x18\x10 \x01(\v2\x15.auction.BidIncrementR\fbidIncrement\x120\n" +
+	"\fnext_min_bid\x18\x11 \x01(\v2\x0e.auction.MoneyR\n" +
+	"nextMinBid\x12\x1b\n" +
+	"\tseller_id\x18\x12 \x01(\tR\bsellerId\x12#\n" +
+	"\rcancel_reason\x18\x13 \x01(\tR\fcancelReason\x12(\n" +
+	"\x10relisted_from_id\x18\x14 \x01(\tR\x0erelistedFromIdJ\x04\b\x04\x10\x05J\x04\b\x05\x10\x06J\x04\b\a\x10\bJ\x04\b\f\x10\r\"\x9a\x01\n" +
+	"\x03Bid\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06lot_id\x18\x02 \x01(\tR\x05lotId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12%\n" +
+	"\x0etimestamp_unix\x18\x05 \x01(\x03R\rtimestampUnix\x12&\n" +
+	"\x06amount\x18\x06 \x01(\v2\x0e.auction.MoneyR\x06amountJ\x04\b\x04\x10\x05\"\x91\x05\n" +
+	"\x10CreateLotRequest\x12+\n" +
+	"\x04name\x18\x01 \x01(\tB\x17\x92A\x06x\xff\x01\x80\x01\x01\xe0A\x02\xbaH\b\xc8\x01\x01r\x03\x18\xff\x01R\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x129\n" +
 	"\n" +
-	"startPrice\x18\x03 \x01(\x01R\n" +
-	"startPrice\x12&\n" +
-	"\x0edurationMinute\x18\x04 \x01(\x03R\x0edurationMinute\"3\n" +
+	"startPrice\x18\t \x01(\v2\x0e.auction.MoneyB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\n" +
+	"startPrice\x12>\n" +
+	"\x0edurationMinute\x18\x04 \x01(\x03B\x16\x92A\ti\x00\x00\x00\x00\x00\x00\xf0?\xe0A\x02\xbaH\x04\"\x02 \x00R\x0edurationMinute\x12B\n" +
+	"\x19soft_close_window_minutes\x18\x05 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x16softCloseWindowMinutes\x12H\n" +
+	"\x1csoft_close_extension_minutes\x18\x06 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x19softCloseExtensionMinutes\x123\n" +
+	"\rreserve_price\x18\n" +
+	" \x01(\v2\x0e.auction.MoneyR\freservePrice\x122\n" +
+	"\rbuy_now_price\x18\v \x01(\v2\x0e.auction.MoneyR\vbuyNowPrice\x12:\n" +
+	"\rbid_increment\x18\f \x01(\v2\x15.auction.BidIncrementR\fbidIncrement\x12/\n" +
+	"\x0fstart_time_unix\x18\r \x01(\x03B\a\xbaH\x04\"\x02(\x00R\rstartTimeUnix\x12\x14\n" +
+	"\x05draft\x18\x0e \x01(\bR\x05draft\x12'\n" +
+	"\x0fidempotency_key\x18\x0f \x01(\tR\x0eidempotencyKeyJ\x04\b\x03\x10\x04J\x04\b\a\x10\bJ\x04\b\b\x10\t\"3\n" +
 	"\x11CreateLotResponse\x12\x1e\n" +
-	"\x03lot\x18\x01 \x01(\v2\f.auction.LotR\x03lot\"&\n" +
-	"\rGetLotRequest\x12\x15\n" +
-	"\x06lot_id\x18\x01 \x01(\tR\x05lotId\"0\n" +
+	"\x03lot\x18\x01 \x01(\v2\f.auction.LotR\x03lot\".\n" +
+	"\rGetLotRequest\x12\x1d\n" +
+	"\x06lot_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05lotId\"0\n" +
 	"\x0eGetLotResponse\x12\x1e\n" +
-	"\x03lot\x18\x01 \x01(\v2\f.auction.LotR\x03lot\"Y\n" +
-	"\x0fPlaceBidRequest\x12\x15\n" +
-	"\x06lot_id\x18\x01 \x01(\tR\x05lotId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
-	"\x06amount\x18\x03 \x01(\x01R\x06amount\"u\n" +
-	"\x10PlaceBidResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\x03lot\x18\x01 \x01(\v2\f.auction.LotR\x03lot\"\xf3\x01\n" +
+	"\x10UpdateLotRequest\x12\x1d\n" +
+	"\x06lot_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05lotId\x12\"\n" +
+	"\x04name\x18\x02 \x01(\tB\x0e\x92A\x03x\xff\x01\xbaH\x05r\x03\x18\xff\x01R\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12=\n" +
+	"\x0eextend_minutes\x18\x04 \x01(\x03B\x16\x92A\tY\x00\x00\x00\x00\x00\x88\xc3@\xbaH\a\"\x05\x18\x90N(\x00R\rextendMinutes\x12;\n" +
+	"\vupdate_mask\x18\x05 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"3\n" +
+	"\x11UpdateLotResponse\x12\x1e\n" +
+	"\x03lot\x18\x01 \x01(\v2\f.auction.LotR\x03lot\"b\n" +
+	"\x10CancelLotRequest\x12\x1d\n" +
+	"\x06lot_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05lotId\x12/\n" +
+	"\x06reason\x18\x02 \x01(\tB\x17\x92A\x06x\xf4\x03\x80\x01\x01\xe0A\x02\xbaH\b\xc8\x01\x01r\x03(\xf4\x03R\x06reason\"3\n" +
+	"\x11CancelLotResponse\x12\x1e\n" +
+	"\x03lot\x18\x01 \x01(\v2\f.auction.LotR\x03lot\"2\n" +
+	"\x11PublishLotRequest\x12\x1d\n" +
+	"\x06lot_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05lotId\"4\n" +
+	"\x12PublishLotResponse\x12\x1e\n" +
+	"\x03lot\x18\x01 \x01(\v2\f.auction.LotR\x03lot\"b\n" +
+	"\x10RelistLotRequest\x12\x1d\n" +
+	"\x06lot_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05lotId\x12/\n" +
+	"\x0edurationMinute\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x0edurationMinute\"3\n" +
+	"\x11RelistLotResponse\x12\x1e\n" +
+	"\x03lot\x18\x01 \x01(\v2\f.auction.LotR\x03lot\"\xcc\x03\n" +
+	"\x0fListLotsRequest\x12'\n" +
+	"\rlegacy_status\x18\x01 \x01(\tB\x02\x18\x01R\flegacyStatus\x12*\n" +
+	"\x06status\x18\r \x01(\x0e2\x12.auction.LotStatusR\x06status\x123\n" +
+	"\x11ending_after_unix\x18\x04 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x0fendingAfterUnix\x125\n" +
+	"\x12ending_before_unix\x18\x05 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x10endingBeforeUnix\x12\x14\n" +
+	"\x05query\x18\x06 \x01(\tR\x05query\x12\x17\n" +
+	"\asort_by\x18\a \x01(\tR\x06sortBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\b \x01(\bR\n" +
+	"descending\x12$\n" +
+	"\tpage_size\x18\t \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\n" +
+	" \x01(\tR\tpageToken\x12+\n" +
+	"\tmin_price\x18\v \x01(\v2\x0e.auction.MoneyR\bminPrice\x12+\n" +
+	"\tmax_price\x18\f \x01(\v2\x0e.auction.MoneyR\bmaxPriceJ\x04\b\x02\x10\x03J\x04\b\x03\x10\x04\"\\\n" +
+	"\x10ListLotsResponse\x12 \n" +
+	"\x04lots\x18\x01 \x03(\v2\f.auction.LotR\x04lots\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xf7\x01\n" +
+	"\x0fPlaceBidRequest\x12\x1d\n" +
+	"\x06lot_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05lotId\x12.\n" +
+	"\auser_id\x18\x02 \x01(\tB\x15\x92A\a\xa2\x02\x04uuid\xbaH\b\xd8\x01\x01r\x03\xb0\x01\x01R\x06userId\x121\n" +
+	"\x06amount\x18\x05 \x01(\v2\x0e.auction.MoneyB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\x06amount\x12-\n" +
+	"\n" +
+	"max_amount\x18\x06 \x01(\v2\x0e.auction.MoneyR\tmaxAmount\x12'\n" +
+	"\x0fidempotency_key\x18\a \x01(\tR\x0eidempotencyKeyJ\x04\b\x03\x10\x04J\x04\b\x04\x10\x05\"y\n" +
+	"\x10PlaceBidResponse\x12\x1c\n" +
+	"\asuccess\x18\x01 \x01(\bB\x02\x18\x01R\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12-\n" +
 	"\vupdated_lot\x18\x03 \x01(\v2\f.auction.LotR\n" +
-	"updatedLot\".\n" +
-	"\x15SubscribeToLotRequest\x12\x15\n" +
-	"\x06lot_id\x18\x01 \x01(\tR\x05lotId\"8\n" +
+	"updatedLot\"\x98\x01\n" +
+	"\x0fListBidsRequest\x12\x1d\n" +
+	"\x06lot_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05lotId\x12$\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12!\n" +
+	"\fmask_bidders\x18\x04 \x01(\bR\vmaskBidders\"\xa2\x01\n" +
+	"\x10ListBidsResponse\x12 \n" +
+	"\x04bids\x18\x01 \x03(\v2\f.auction.BidR\x04bids\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_bids\x18\x03 \x01(\x03R\ttotalBids\x12%\n" +
+	"\x0eunique_bidders\x18\x04 \x01(\x03R\runiqueBidders\"6\n" +
+	"\x15SubscribeToLotRequest\x12\x1d\n" +
+	"\x06lot_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05lotId\"8\n" +
 	"\x16SubscribeToLotResponse\x12\x1e\n" +
-	"\x03lot\x18\x01 \x01(\v2\f.auction.LotR\x03lot2\xad\x03\n" +
+	"\x03lot\x18\x01 \x01(\v2\f.auction.LotR\x03lot\"!\n" +
+	"\x1fSubscribeToNotificationsRequest\"V\n" +
+	" SubscribeToNotificationsResponse\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1e\n" +
+	"\x03lot\x18\x02 \x01(\v2\f.auction.LotR\x03lot\"n\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12&\n" +
+	"\x0fcreated_at_unix\x18\x03 \x01(\x03R\rcreatedAtUnix\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\"\xad\x01\n" +
+	"\x0fRegisterRequest\x12%\n" +
+	"\busername\x18\x01 \x01(\tB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\busername\x120\n" +
+	"\bpassword\x18\x02 \x01(\tB\x14\x92A\x05xH\x80\x01\b\xe0A\x02\xbaH\x06r\x04 \b(HR\bpassword\x12A\n" +
+	"\x04role\x18\x03 \x01(\tB-\x92A\x12\xf2\x02\x06bidder\xf2\x02\x06seller\xbaH\x15\xd8\x01\x01r\x10R\x06bidderR\x06sellerR\x04role\"5\n" +
+	"\x10RegisterResponse\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.auction.UserR\x04user\"\\\n" +
+	"\fLoginRequest\x12%\n" +
+	"\busername\x18\x01 \x01(\tB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\busername\x12%\n" +
+	"\bpassword\x18\x02 \x01(\tB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\bpassword\"}\n" +
+	"\rLoginResponse\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12&\n" +
+	"\x0fexpires_at_unix\x18\x02 \x01(\x03R\rexpiresAtUnix\x12!\n" +
+	"\x04user\x18\x03 \x01(\v2\r.auction.UserR\x04user*\xcc\x01\n" +
+	"\tLotStatus\x12\x1a\n" +
+	"\x16LOT_STATUS_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10LOT_STATUS_DRAFT\x10\x01\x12\x18\n" +
+	"\x14LOT_STATUS_SCHEDULED\x10\x02\x12\x15\n" +
+	"\x11LOT_STATUS_ACTIVE\x10\x03\x12\x16\n" +
+	"\x12LOT_STATUS_CLOSING\x10\x04\x12\x13\n" +
+	"\x0fLOT_STATUS_SOLD\x10\x05\x12\x15\n" +
+	"\x11LOT_STATUS_UNSOLD\x10\x06\x12\x18\n" +
+	"\x14LOT_STATUS_CANCELLED\x10\a2\xb7\t\n" +
 	"\x0eAuctionService\x12[\n" +
 	"\tCreateLot\x12\x19.auction.CreateLotRequest\x1a\x1a.auction.CreateLotResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/api/v1/lots\x12X\n" +
-	"\x06GetLot\x12\x16.auction.GetLotRequest\x1a\x17.auction.GetLotResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/lots/{lot_id}\x12f\n" +
-	"\bPlaceBid\x12\x18.auction.PlaceBidRequest\x1a\x19.auction.PlaceBidResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/api/v1/lots/{lot_id}/bids\x12|\n" +
-	"\x0eSubscribeToLot\x12\x1e.auction.SubscribeToLotRequest\x1a\x1f.auction.SubscribeToLotResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/lots/{lot_id}/subscribe0\x01B&Z$github.com/auctiongithub/gen/auctionb\x06proto3"
+	"\x06GetLot\x12\x16.auction.GetLotRequest\x1a\x17.auction.GetLotResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/lots/{lot_id}\x12U\n" +
+	"\bListLots\x12\x18.auction.ListLotsRequest\x1a\x19.auction.ListLotsResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/api/v1/lots\x12d\n" +
+	"\tUpdateLot\x12\x19.auction.UpdateLotRequest\x1a\x1a.auction.UpdateLotResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*2\x15/api/v1/lots/{lot_id}\x12k\n" +
+	"\tCancelLot\x12\x19.auction.CancelLotRequest\x1a\x1a.auction.CancelLotResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/lots/{lot_id}:cancel\x12o\n" +
+	"\n" +
+	"PublishLot\x12\x1a.auction.PublishLotRequest\x1a\x1b.auction.PublishLotResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/v1/lots/{lot_id}:publish\x12k\n" +
+	"\tRelistLot\x12\x19.auction.RelistLotRequest\x1a\x1a.auction.RelistLotResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/lots/{lot_id}:relist\x12f\n" +
+	"\bPlaceBid\x12\x18.auction.PlaceBidRequest\x1a\x19.auction.PlaceBidResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/api/v1/lots/{lot_id}/bids\x12c\n" +
+	"\bListBids\x12\x18.auction.ListBidsRequest\x1a\x19.auction.ListBidsResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/api/v1/lots/{lot_id}/bids\x12|\n" +
+	"\x0eSubscribeToLot\x12\x1e.auction.SubscribeToLotRequest\x1a\x1f.auction.SubscribeToLotResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/lots/{lot_id}/subscribe0\x01\x12\x9a\x01\n" +
+	"\x18SubscribeToNotifications\x12(.auction.SubscribeToNotificationsRequest\x1a).auction.SubscribeToNotificationsResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/notifications/subscribe0\x012\xc7\x01\n" +
+	"\vUserService\x12a\n" +
+	"\bRegister\x12\x18.auction.RegisterRequest\x1a\x19.auction.RegisterResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/api/v1/auth/register\x12U\n" +
+	"\x05Login\x12\x15.auction.LoginRequest\x1a\x16.auction.LoginResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/api/v1/auth/loginB&Z$github.com/auctiongithub/gen/auctionb\x06proto3"
 
 var (
 	file_auction_auction_proto_rawDescOnce sync.Once
@@ -593,36 +2374,107 @@ func file_auction_auction_proto_rawDescGZIP() []byte {
 	return file_auction_auction_proto_rawDescData
 }
 
-var file_auction_auction_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_auction_auction_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_auction_auction_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_auction_auction_proto_goTypes = []any{
-	(*Lot)(nil),                    // 0: auction.Lot
-	(*CreateLotRequest)(nil),       // 1: auction.CreateLotRequest
-	(*CreateLotResponse)(nil),      // 2: auction.CreateLotResponse
-	(*GetLotRequest)(nil),          // 3: auction.GetLotRequest
-	(*GetLotResponse)(nil),         // 4: auction.GetLotResponse
-	(*PlaceBidRequest)(nil),        // 5: auction.PlaceBidRequest
-	(*PlaceBidResponse)(nil),       // 6: auction.PlaceBidResponse
-	(*SubscribeToLotRequest)(nil),  // 7: auction.SubscribeToLotRequest
-	(*SubscribeToLotResponse)(nil), // 8: auction.SubscribeToLotResponse
+	(LotStatus)(0),                           // 0: auction.LotStatus
+	(*Money)(nil),                            // 1: auction.Money
+	(*BidIncrement)(nil),                     // 2: auction.BidIncrement
+	(*BidIncrementTier)(nil),                 // 3: auction.BidIncrementTier
+	(*Lot)(nil),                              // 4: auction.Lot
+	(*Bid)(nil),                              // 5: auction.Bid
+	(*CreateLotRequest)(nil),                 // 6: auction.CreateLotRequest
+	(*CreateLotResponse)(nil),                // 7: auction.CreateLotResponse
+	(*GetLotRequest)(nil),                    // 8: auction.GetLotRequest
+	(*GetLotResponse)(nil),                   // 9: auction.GetLotResponse
+	(*UpdateLotRequest)(nil),                 // 10: auction.UpdateLotRequest
+	(*UpdateLotResponse)(nil),                // 11: auction.UpdateLotResponse
+	(*CancelLotRequest)(nil),                 // 12: auction.CancelLotRequest
+	(*CancelLotResponse)(nil),                // 13: auction.CancelLotResponse
+	(*PublishLotRequest)(nil),                // 14: auction.PublishLotRequest
+	(*PublishLotResponse)(nil),               // 15: auction.PublishLotResponse
+	(*RelistLotRequest)(nil),                 // 16: auction.RelistLotRequest
+	(*RelistLotResponse)(nil),                // 17: auction.RelistLotResponse
+	(*ListLotsRequest)(nil),                  // 18: auction.ListLotsRequest
+	(*ListLotsResponse)(nil),                 // 19: auction.ListLotsResponse
+	(*PlaceBidRequest)(nil),                  // 20: auction.PlaceBidRequest
+	(*PlaceBidResponse)(nil),                 // 21: auction.PlaceBidResponse
+	(*ListBidsRequest)(nil),                  // 22: auction.ListBidsRequest
+	(*ListBidsResponse)(nil),                 // 23: auction.ListBidsResponse
+	(*SubscribeToLotRequest)(nil),            // 24: auction.SubscribeToLotRequest
+	(*SubscribeToLotResponse)(nil),           // 25: auction.SubscribeToLotResponse
+	(*SubscribeToNotificationsRequest)(nil),  // 26: auction.SubscribeToNotificationsRequest
+	(*SubscribeToNotificationsResponse)(nil), // 27: auction.SubscribeToNotificationsResponse
+	(*User)(nil),                             // 28: auction.User
+	(*RegisterRequest)(nil),                  // 29: auction.RegisterRequest
+	(*RegisterResponse)(nil),                 // 30: auction.RegisterResponse
+	(*LoginRequest)(nil),                     // 31: auction.LoginRequest
+	(*LoginResponse)(nil),                    // 32: auction.LoginResponse
+	(*fieldmaskpb.FieldMask)(nil),            // 33: google.protobuf.FieldMask
 }
 var file_auction_auction_proto_depIdxs = []int32{
-	0, // 0: auction.CreateLotResponse.lot:type_name -> auction.Lot
-	0, // 1: auction.GetLotResponse.lot:type_name -> auction.Lot
-	0, // 2: auction.PlaceBidResponse.updated_lot:type_name -> auction.Lot
-	0, // 3: auction.SubscribeToLotResponse.lot:type_name -> auction.Lot
-	1, // 4: auction.AuctionService.CreateLot:input_type -> auction.CreateLotRequest
-	3, // 5: auction.AuctionService.GetLot:input_type -> auction.GetLotRequest
-	5, // 6: auction.AuctionService.PlaceBid:input_type -> auction.PlaceBidRequest
-	7, // 7: auction.AuctionService.SubscribeToLot:input_type -> auction.SubscribeToLotRequest
-	2, // 8: auction.AuctionService.CreateLot:output_type -> auction.CreateLotResponse
-	4, // 9: auction.AuctionService.GetLot:output_type -> auction.GetLotResponse
-	6, // 10: auction.AuctionService.PlaceBid:output_type -> auction.PlaceBidResponse
-	8, // 11: auction.AuctionService.SubscribeToLot:output_type -> auction.SubscribeToLotResponse
-	8, // [8:12] is the sub-list for method output_type
-	4, // [4:8] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	3,  // 0: auction.BidIncrement.tiers:type_name -> auction.BidIncrementTier
+	1,  // 1: auction.Lot.startPrice:type_name -> auction.Money
+	1,  // 2: auction.Lot.currentPrice:type_name -> auction.Money
+	0,  // 3: auction.Lot.status:type_name -> auction.LotStatus
+	1,  // 4: auction.Lot.buy_now_price:type_name -> auction.Money
+	2,  // 5: auction.Lot.bid_increment:type_name -> auction.BidIncrement
+	1,  // 6: auction.Lot.next_min_bid:type_name -> auction.Money
+	1,  // 7: auction.Bid.amount:type_name -> auction.Money
+	1,  // 8: auction.CreateLotRequest.startPrice:type_name -> auction.Money
+	1,  // 9: auction.CreateLotRequest.reserve_price:type_name -> auction.Money
+	1,  // 10: auction.CreateLotRequest.buy_now_price:type_name -> auction.Money
+	2,  // 11: auction.CreateLotRequest.bid_increment:type_name -> auction.BidIncrement
+	4,  // 12: auction.CreateLotResponse.lot:type_name -> auction.Lot
+	4,  // 13: auction.GetLotResponse.lot:type_name -> auction.Lot
+	33, // 14: auction.UpdateLotRequest.update_mask:type_name -> google.protobuf.FieldMask
+	4,  // 15: auction.UpdateLotResponse.lot:type_name -> auction.Lot
+	4,  // 16: auction.CancelLotResponse.lot:type_name -> auction.Lot
+	4,  // 17: auction.PublishLotResponse.lot:type_name -> auction.Lot
+	4,  // 18: auction.RelistLotResponse.lot:type_name -> auction.Lot
+	0,  // 19: auction.ListLotsRequest.status:type_name -> auction.LotStatus
+	1,  // 20: auction.ListLotsRequest.min_price:type_name -> auction.Money
+	1,  // 21: auction.ListLotsRequest.max_price:type_name -> auction.Money
+	4,  // 22: auction.ListLotsResponse.lots:type_name -> auction.Lot
+	1,  // 23: auction.PlaceBidRequest.amount:type_name -> auction.Money
+	1,  // 24: auction.PlaceBidRequest.max_amount:type_name -> auction.Money
+	4,  // 25: auction.PlaceBidResponse.updated_lot:type_name -> auction.Lot
+	5,  // 26: auction.ListBidsResponse.bids:type_name -> auction.Bid
+	4,  // 27: auction.SubscribeToLotResponse.lot:type_name -> auction.Lot
+	4,  // 28: auction.SubscribeToNotificationsResponse.lot:type_name -> auction.Lot
+	28, // 29: auction.RegisterResponse.user:type_name -> auction.User
+	28, // 30: auction.LoginResponse.user:type_name -> auction.User
+	6,  // 31: auction.AuctionService.CreateLot:input_type -> auction.CreateLotRequest
+	8,  // 32: auction.AuctionService.GetLot:input_type -> auction.GetLotRequest
+	18, // 33: auction.AuctionService.ListLots:input_type -> auction.ListLotsRequest
+	10, // 34: auction.AuctionService.UpdateLot:input_type -> auction.UpdateLotRequest
+	12, // 35: auction.AuctionService.CancelLot:input_type -> auction.CancelLotRequest
+	14, // 36: auction.AuctionService.PublishLot:input_type -> auction.PublishLotRequest
+	16, // 37: auction.AuctionService.RelistLot:input_type -> auction.RelistLotRequest
+	20, // 38: auction.AuctionService.PlaceBid:input_type -> auction.PlaceBidRequest
+	22, // 39: auction.AuctionService.ListBids:input_type -> auction.ListBidsRequest
+	24, // 40: auction.AuctionService.SubscribeToLot:input_type -> auction.SubscribeToLotRequest
+	26, // 41: auction.AuctionService.SubscribeToNotifications:input_type -> auction.SubscribeToNotificationsRequest
+	29, // 42: auction.UserService.Register:input_type -> auction.RegisterRequest
+	31, // 43: auction.UserService.Login:input_type -> auction.LoginRequest
+	7,  // 44: auction.AuctionService.CreateLot:output_type -> auction.CreateLotResponse
+	9,  // 45: auction.AuctionService.GetLot:output_type -> auction.GetLotResponse
+	19, // 46: auction.AuctionService.ListLots:output_type -> auction.ListLotsResponse
+	11, // 47: auction.AuctionService.UpdateLot:output_type -> auction.UpdateLotResponse
+	13, // 48: auction.AuctionService.CancelLot:output_type -> auction.CancelLotResponse
+	15, // 49: auction.AuctionService.PublishLot:output_type -> auction.PublishLotResponse
+	17, // 50: auction.AuctionService.RelistLot:output_type -> auction.RelistLotResponse
+	21, // 51: auction.AuctionService.PlaceBid:output_type -> auction.PlaceBidResponse
+	23, // 52: auction.AuctionService.ListBids:output_type -> auction.ListBidsResponse
+	25, // 53: auction.AuctionService.SubscribeToLot:output_type -> auction.SubscribeToLotResponse
+	27, // 54: auction.AuctionService.SubscribeToNotifications:output_type -> auction.SubscribeToNotificationsResponse
+	30, // 55: auction.UserService.Register:output_type -> auction.RegisterResponse
+	32, // 56: auction.UserService.Login:output_type -> auction.LoginResponse
+	44, // [44:57] is the sub-list for method output_type
+	31, // [31:44] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_auction_auction_proto_init() }
@@ -635,13 +2487,14 @@ func file_auction_auction_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auction_auction_proto_rawDesc), len(file_auction_auction_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   9,
+			NumEnums:      1,
+			NumMessages:   32,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_auction_auction_proto_goTypes,
 		DependencyIndexes: file_auction_auction_proto_depIdxs,
+		EnumInfos:         file_auction_auction_proto_enumTypes,
 		MessageInfos:      file_auction_auction_proto_msgTypes,
 	}.Build()
 	File_auction_auction_proto = out.File
@@ -19,10 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuctionService_CreateLot_FullMethodName      = "/auction.AuctionService/CreateLot"
-	AuctionService_GetLot_FullMethodName         = "/auction.AuctionService/GetLot"
-	AuctionService_PlaceBid_FullMethodName       = "/auction.AuctionService/PlaceBid"
-	AuctionService_SubscribeToLot_FullMethodName = "/auction.AuctionService/SubscribeToLot"
+	AuctionService_CreateLot_FullMethodName                = "/auction.AuctionService/CreateLot"
+	AuctionService_GetLot_FullMethodName                   = "/auction.AuctionService/GetLot"
+	AuctionService_ListLots_FullMethodName                 = "/auction.AuctionService/ListLots"
+	AuctionService_UpdateLot_FullMethodName                = "/auction.AuctionService/UpdateLot"
+	AuctionService_CancelLot_FullMethodName                = "/auction.AuctionService/CancelLot"
+	AuctionService_PublishLot_FullMethodName               = "/auction.AuctionService/PublishLot"
+	AuctionService_RelistLot_FullMethodName                = "/auction.AuctionService/RelistLot"
+	AuctionService_PlaceBid_FullMethodName                 = "/auction.AuctionService/PlaceBid"
+	AuctionService_ListBids_FullMethodName                 = "/auction.AuctionService/ListBids"
+	AuctionService_SubscribeToLot_FullMethodName           = "/auction.AuctionService/SubscribeToLot"
+	AuctionService_SubscribeToNotifications_FullMethodName = "/auction.AuctionService/SubscribeToNotifications"
 )
 
 // AuctionServiceClient is the client API for AuctionService service.
@@ -33,8 +40,15 @@ const (
 type AuctionServiceClient interface {
 	CreateLot(ctx context.Context, in *CreateLotRequest, opts ...grpc.CallOption) (*CreateLotResponse, error)
 	GetLot(ctx context.Context, in *GetLotRequest, opts ...grpc.CallOption) (*GetLotResponse, error)
+	ListLots(ctx context.Context, in *ListLotsRequest, opts ...grpc.CallOption) (*ListLotsResponse, error)
+	UpdateLot(ctx context.Context, in *UpdateLotRequest, opts ...grpc.CallOption) (*UpdateLotResponse, error)
+	CancelLot(ctx context.Context, in *CancelLotRequest, opts ...grpc.CallOption) (*CancelLotResponse, error)
+	PublishLot(ctx context.Context, in *PublishLotRequest, opts ...grpc.CallOption) (*PublishLotResponse, error)
+	RelistLot(ctx context.Context, in *RelistLotRequest, opts ...grpc.CallOption) (*RelistLotResponse, error)
 	PlaceBid(ctx context.Context, in *PlaceBidRequest, opts ...grpc.CallOption) (*PlaceBidResponse, error)
+	ListBids(ctx context.Context, in *ListBidsRequest, opts ...grpc.CallOption) (*ListBidsResponse, error)
 	SubscribeToLot(ctx context.Context, in *SubscribeToLotRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeToLotResponse], error)
+	SubscribeToNotifications(ctx context.Context, in *SubscribeToNotificationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeToNotificationsResponse], error)
 }
 
 type auctionServiceClient struct {
@@ -65,6 +79,56 @@ func (c *auctionServiceClient) GetLot(ctx context.Context, in *GetLotRequest, op
 	return out, nil
 }
 
+func (c *auctionServiceClient) ListLots(ctx context.Context, in *ListLotsRequest, opts ...grpc.CallOption) (*ListLotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLotsResponse)
+	err := c.cc.Invoke(ctx, AuctionService_ListLots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionServiceClient) UpdateLot(ctx context.Context, in *UpdateLotRequest, opts ...grpc.CallOption) (*UpdateLotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateLotResponse)
+	err := c.cc.Invoke(ctx, AuctionService_UpdateLot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionServiceClient) CancelLot(ctx context.Context, in *CancelLotRequest, opts ...grpc.CallOption) (*CancelLotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelLotResponse)
+	err := c.cc.Invoke(ctx, AuctionService_CancelLot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionServiceClient) PublishLot(ctx context.Context, in *PublishLotRequest, opts ...grpc.CallOption) (*PublishLotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishLotResponse)
+	err := c.cc.Invoke(ctx, AuctionService_PublishLot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionServiceClient) RelistLot(ctx context.Context, in *RelistLotRequest, opts ...grpc.CallOption) (*RelistLotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RelistLotResponse)
+	err := c.cc.Invoke(ctx, AuctionService_RelistLot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *auctionServiceClient) PlaceBid(ctx context.Context, in *PlaceBidRequest, opts ...grpc.CallOption) (*PlaceBidResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PlaceBidResponse)
@@ -75,6 +139,16 @@ func (c *auctionServiceClient) PlaceBid(ctx context.Context, in *PlaceBidRequest
 	return out, nil
 }
 
+func (c *auctionServiceClient) ListBids(ctx context.Context, in *ListBidsRequest, opts ...grpc.CallOption) (*ListBidsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBidsResponse)
+	err := c.cc.Invoke(ctx, AuctionService_ListBids_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *auctionServiceClient) SubscribeToLot(ctx context.Context, in *SubscribeToLotRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeToLotResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &AuctionService_ServiceDesc.Streams[0], AuctionService_SubscribeToLot_FullMethodName, cOpts...)
@@ -94,6 +168,25 @@ func (c *auctionServiceClient) SubscribeToLot(ctx context.Context, in *Subscribe
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type AuctionService_SubscribeToLotClient = grpc.ServerStreamingClient[SubscribeToLotResponse]
 
+func (c *auctionServiceClient) SubscribeToNotifications(ctx context.Context, in *SubscribeToNotificationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeToNotificationsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AuctionService_ServiceDesc.Streams[1], AuctionService_SubscribeToNotifications_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeToNotificationsRequest, SubscribeToNotificationsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AuctionService_SubscribeToNotificationsClient = grpc.ServerStreamingClient[SubscribeToNotificationsResponse]
+
 // AuctionServiceServer is the server API for AuctionService service.
 // All implementations must embed UnimplementedAuctionServiceServer
 // for forward compatibility.
@@ -102,8 +195,15 @@ type AuctionService_SubscribeToLotClient = grpc.ServerStreamingClient[SubscribeT
 type AuctionServiceServer interface {
 	CreateLot(context.Context, *CreateLotRequest) (*CreateLotResponse, error)
 	GetLot(context.Context, *GetLotRequest) (*GetLotResponse, error)
+	ListLots(context.Context, *ListLotsRequest) (*ListLotsResponse, error)
+	UpdateLot(context.Context, *UpdateLotRequest) (*UpdateLotResponse, error)
+	CancelLot(context.Context, *CancelLotRequest) (*CancelLotResponse, error)
+	PublishLot(context.Context, *PublishLotRequest) (*PublishLotResponse, error)
+	RelistLot(context.Context, *RelistLotRequest) (*RelistLotResponse, error)
 	PlaceBid(context.Context, *PlaceBidRequest) (*PlaceBidResponse, error)
+	ListBids(context.Context, *ListBidsRequest) (*ListBidsResponse, error)
 	SubscribeToLot(*SubscribeToLotRequest, grpc.ServerStreamingServer[SubscribeToLotResponse]) error
+	SubscribeToNotifications(*SubscribeToNotificationsRequest, grpc.ServerStreamingServer[SubscribeToNotificationsResponse]) error
 	mustEmbedUnimplementedAuctionServiceServer()
 }
 
@@ -120,12 +220,33 @@ func (UnimplementedAuctionServiceServer) CreateLot(context.Context, *CreateLotRe
 func (UnimplementedAuctionServiceServer) GetLot(context.Context, *GetLotRequest) (*GetLotResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLot not implemented")
 }
+func (UnimplementedAuctionServiceServer) ListLots(context.Context, *ListLotsRequest) (*ListLotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLots not implemented")
+}
+func (UnimplementedAuctionServiceServer) UpdateLot(context.Context, *UpdateLotRequest) (*UpdateLotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLot not implemented")
+}
+func (UnimplementedAuctionServiceServer) CancelLot(context.Context, *CancelLotRequest) (*CancelLotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelLot not implemented")
+}
+func (UnimplementedAuctionServiceServer) PublishLot(context.Context, *PublishLotRequest) (*PublishLotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishLot not implemented")
+}
+func (UnimplementedAuctionServiceServer) RelistLot(context.Context, *RelistLotRequest) (*RelistLotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RelistLot not implemented")
+}
 func (UnimplementedAuctionServiceServer) PlaceBid(context.Context, *PlaceBidRequest) (*PlaceBidResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PlaceBid not implemented")
 }
+func (UnimplementedAuctionServiceServer) ListBids(context.Context, *ListBidsRequest) (*ListBidsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBids not implemented")
+}
 func (UnimplementedAuctionServiceServer) SubscribeToLot(*SubscribeToLotRequest, grpc.ServerStreamingServer[SubscribeToLotResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeToLot not implemented")
 }
+func (UnimplementedAuctionServiceServer) SubscribeToNotifications(*SubscribeToNotificationsRequest, grpc.ServerStreamingServer[SubscribeToNotificationsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeToNotifications not implemented")
+}
 func (UnimplementedAuctionServiceServer) mustEmbedUnimplementedAuctionServiceServer() {}
 func (UnimplementedAuctionServiceServer) testEmbeddedByValue()                        {}
 
@@ -183,6 +304,96 @@ func _AuctionService_GetLot_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuctionService_ListLots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).ListLots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_ListLots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).ListLots(ctx, req.(*ListLotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionService_UpdateLot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).UpdateLot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_UpdateLot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).UpdateLot(ctx, req.(*UpdateLotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionService_CancelLot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelLotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).CancelLot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_CancelLot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).CancelLot(ctx, req.(*CancelLotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionService_PublishLot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishLotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).PublishLot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_PublishLot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).PublishLot(ctx, req.(*PublishLotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionService_RelistLot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelistLotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).RelistLot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_RelistLot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).RelistLot(ctx, req.(*RelistLotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AuctionService_PlaceBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PlaceBidRequest)
 	if err := dec(in); err != nil {
@@ -201,6 +412,24 @@ func _AuctionService_PlaceBid_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuctionService_ListBids_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBidsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).ListBids(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_ListBids_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).ListBids(ctx, req.(*ListBidsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AuctionService_SubscribeToLot_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SubscribeToLotRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -212,6 +441,17 @@ func _AuctionService_SubscribeToLot_Handler(srv interface{}, stream grpc.ServerS
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type AuctionService_SubscribeToLotServer = grpc.ServerStreamingServer[SubscribeToLotResponse]
 
+func _AuctionService_SubscribeToNotifications_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeToNotificationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AuctionServiceServer).SubscribeToNotifications(m, &grpc.GenericServerStream[SubscribeToNotificationsRequest, SubscribeToNotificationsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AuctionService_SubscribeToNotificationsServer = grpc.ServerStreamingServer[SubscribeToNotificationsResponse]
+
 // AuctionService_ServiceDesc is the grpc.ServiceDesc for AuctionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -227,10 +467,34 @@ var AuctionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLot",
 			Handler:    _AuctionService_GetLot_Handler,
 		},
+		{
+			MethodName: "ListLots",
+			Handler:    _AuctionService_ListLots_Handler,
+		},
+		{
+			MethodName: "UpdateLot",
+			Handler:    _AuctionService_UpdateLot_Handler,
+		},
+		{
+			MethodName: "CancelLot",
+			Handler:    _AuctionService_CancelLot_Handler,
+		},
+		{
+			MethodName: "PublishLot",
+			Handler:    _AuctionService_PublishLot_Handler,
+		},
+		{
+			MethodName: "RelistLot",
+			Handler:    _AuctionService_RelistLot_Handler,
+		},
 		{
 			MethodName: "PlaceBid",
 			Handler:    _AuctionService_PlaceBid_Handler,
 		},
+		{
+			MethodName: "ListBids",
+			Handler:    _AuctionService_ListBids_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -238,6 +502,151 @@ var AuctionService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _AuctionService_SubscribeToLot_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribeToNotifications",
+			Handler:       _AuctionService_SubscribeToNotifications_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "auction/auction.proto",
+}
+
+const (
+	UserService_Register_FullMethodName = "/auction.UserService/Register"
+	UserService_Login_FullMethodName    = "/auction.UserService/Login"
+)
+
+// UserServiceClient is the client API for UserService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UserServiceClient interface {
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+}
+
+type userServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserServiceClient(cc grpc.ClientConnInterface) UserServiceClient {
+	return &userServiceClient{cc}
+}
+
+func (c *userServiceClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterResponse)
+	err := c.cc.Invoke(ctx, UserService_Register_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, UserService_Login_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+// All implementations must embed UnimplementedUserServiceServer
+// for forward compatibility.
+type UserServiceServer interface {
+	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+// UnimplementedUserServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUserServiceServer struct{}
+
+func (UnimplementedUserServiceServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedUserServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
+func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserServiceServer will
+// result in compilation errors.
+type UnsafeUserServiceServer interface {
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
+	// If the following call pancis, it indicates UnimplementedUserServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UserService_ServiceDesc, srv)
+}
+
+func _UserService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "auction.UserService",
+	HandlerType: (*UserServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _UserService_Register_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _UserService_Login_Handler,
+		},
 	},
+	Streams:  []grpc.StreamDesc{},
 	Metadata: "auction/auction.proto",
 }
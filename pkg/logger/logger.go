@@ -27,7 +27,7 @@ func New(env string, level slog.Level) *slog.Logger {
 		handler = slog.NewJSONHandler(os.Stdout, opts)
 	}
 
-	logger := slog.New(handler)
+	logger := slog.New(contextHandler{handler})
 	return logger
 }
 
@@ -0,0 +1,62 @@
+// Package token описывает формат токенов доступа: JWT, подписанные HS256
+// общим секретом. auction-service их выпускает, api-gateway проверяет на входе.
+package token
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidToken = errors.New("invalid access token")
+
+// Claims — пользователь, которого удостоверяет токен.
+type Claims struct {
+	UserID   string
+	Username string
+	Role     string
+}
+
+type jwtClaims struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// Sign выпускает токен с заданным сроком действия.
+func Sign(secret []byte, c Claims, issuedAt, expiresAt time.Time) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims{
+		Username: c.Username,
+		Role:     c.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   c.UserID,
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+
+	signed, err := token.SignedString(secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return signed, nil
+}
+
+// Verify проверяет подпись и срок действия токена. Role остаётся пустой
+// у токенов, выпущенных до появления ролей.
+func Verify(secret []byte, token string) (Claims, error) {
+	var c jwtClaims
+	_, err := jwt.ParseWithClaims(token, &c, func(*jwt.Token) (any, error) {
+		return secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}), jwt.WithExpirationRequired())
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if c.Subject == "" {
+		return Claims{}, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	return Claims{UserID: c.Subject, Username: c.Username, Role: c.Role}, nil
+}